@@ -0,0 +1,46 @@
+package lexorank
+
+// Algorithm derives a key between two keys.
+type Algorithm interface {
+	// Between returns a key strictly between prevKey and nextKey. An empty prevKey or nextKey means the start or
+	// the end of the keyspace, and at least one of them is not empty.
+	// g is the Generator calling the algorithm, whose configuration such as the CharacterSet can be used.
+	Between(g *Generator, prevKey, nextKey Key) (Key, error)
+}
+
+var (
+	// HeuristicAlgorithm splices characters of the keys, choosing characters with the MidpointStrategy.
+	// It is the default algorithm.
+	HeuristicAlgorithm Algorithm = heuristicAlgorithm{}
+	// FractionalAlgorithm generates the exact midpoint of the fractions represented by the keys.
+	// See WithFractionalPositions.
+	FractionalAlgorithm Algorithm = fractionalAlgorithm{}
+	// MudderAlgorithm keeps the longest common prefix and subdivides the rest proportionally like mudder.js,
+	// which generates the shortest key between the keys. See WithMudderSubdivision.
+	MudderAlgorithm Algorithm = mudderAlgorithm{}
+)
+
+// WithAlgorithm returns a GeneratorOption that sets the Algorithm of the Generator.
+func WithAlgorithm(a Algorithm) GeneratorOption {
+	return func(g *Generator) {
+		g.algorithm = a
+	}
+}
+
+type heuristicAlgorithm struct{}
+
+func (heuristicAlgorithm) Between(g *Generator, prevKey, nextKey Key) (Key, error) {
+	return g.betweenHeuristic(prevKey, nextKey)
+}
+
+type fractionalAlgorithm struct{}
+
+func (fractionalAlgorithm) Between(g *Generator, prevKey, nextKey Key) (Key, error) {
+	return g.betweenFractional(prevKey, nextKey)
+}
+
+type mudderAlgorithm struct{}
+
+func (mudderAlgorithm) Between(g *Generator, prevKey, nextKey Key) (Key, error) {
+	return g.betweenMudder(prevKey, nextKey)
+}
@@ -0,0 +1,46 @@
+package lexorank
+
+import (
+	"strings"
+	"testing"
+)
+
+// recordingAlgorithm delegates to HeuristicAlgorithm, recording the calls.
+type recordingAlgorithm struct {
+	calls []string
+}
+
+func (a *recordingAlgorithm) Between(g *Generator, prevKey, nextKey Key) (Key, error) {
+	a.calls = append(a.calls, string(prevKey)+"-"+string(nextKey))
+	return HeuristicAlgorithm.Between(g, prevKey, nextKey)
+}
+
+func TestWithAlgorithm(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	for _, tt := range []struct {
+		algorithm Algorithm
+		want      Key
+	}{
+		{HeuristicAlgorithm, "14"},
+		{FractionalAlgorithm, "145"},
+		{MudderAlgorithm, "15"},
+	} {
+		g := NewGenerator(WithCharacterSet(charSet), WithAlgorithm(tt.algorithm))
+		key, err := g.Between("1", "19")
+		noError(t, err)
+		equalKey(t, key, tt.want)
+	}
+
+	a := &recordingAlgorithm{}
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithAlgorithm(a))
+	key, err := g.Initial()
+	noError(t, err)
+	key, err = g.Next(key)
+	noError(t, err)
+	equalKey(t, key, "6")
+	if got := strings.Join(a.calls, ","); got != "5-" {
+		t.Fatalf("unexpected calls: %s", got)
+	}
+}
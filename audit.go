@@ -0,0 +1,163 @@
+package lexorank
+
+import (
+	"cmp"
+	"slices"
+)
+
+// AuditIssue is a problem found in a key by Audit.
+type AuditIssue struct {
+	// Index is the position of the key in the audited keys.
+	Index  int
+	Key    Key
+	Reason AuditReason
+}
+
+// AuditReason is the reason of an AuditIssue.
+type AuditReason string
+
+const (
+	// AuditInvalidKey means that the key is empty or has a character out of the character set.
+	AuditInvalidKey AuditReason = "invalid key"
+	// AuditDuplicateKey means that the key is the same as a preceding key.
+	AuditDuplicateKey AuditReason = "duplicate key"
+	// AuditOutOfOrder means that the key does not sort in the expected order.
+	AuditOutOfOrder AuditReason = "out of order"
+	// AuditNoRoom means that the key is in order but leaves no room for the repaired keys before it.
+	AuditNoRoom AuditReason = "no room"
+)
+
+// AuditRepair is a new key for the key at Index that fixes the issues.
+type AuditRepair struct {
+	Index int
+	KeyChange
+}
+
+// AuditReport is the result of Audit.
+type AuditReport struct {
+	Issues []AuditIssue
+	// Repairs is the plan to fix all issues by changing as few keys as possible.
+	Repairs []AuditRepair
+}
+
+// Audit checks keys that are expected to be sorted, such as keys ordered by another column of a table,
+// and reports invalid, duplicate and out-of-order keys along with a plan to repair them.
+// The repair plan keeps the longest sequence of sorted keys and generates new keys for the rest.
+func (g *Generator) Audit(keys []Key) (AuditReport, error) {
+	d := newKeyDigits(g.characterSet)
+	var report AuditReport
+
+	valid := make([]bool, len(keys))
+	seen := make(map[Key]bool, len(keys))
+	raw := make([]Key, len(keys))
+	for i, key := range keys {
+		var err error
+		if raw[i], err = g.rawKey(key); err == nil {
+			_, err = d.toInt(raw[i], len([]rune(raw[i])))
+		}
+		switch {
+		case key == "" || err != nil:
+			report.Issues = append(report.Issues, AuditIssue{i, key, AuditInvalidKey})
+		case seen[key]:
+			report.Issues = append(report.Issues, AuditIssue{i, key, AuditDuplicateKey})
+		default:
+			valid[i] = true
+		}
+		seen[key] = true
+	}
+
+	keep := longestSortedKeys(keys, valid)
+	for i, key := range keys {
+		if valid[i] && !keep[i] {
+			report.Issues = append(report.Issues, AuditIssue{i, key, AuditOutOfOrder})
+		}
+	}
+
+	var prev Key
+	var run []int
+	flush := func(next Key) error {
+		if len(run) == 0 {
+			return nil
+		}
+		newKeys, err := spreadWithLength(d, prev, next, len(run))
+		if err != nil {
+			return err
+		}
+		for j, i := range run {
+			key, err := g.finishKey(newKeys[j])
+			if err != nil {
+				return err
+			}
+			report.Repairs = append(report.Repairs, AuditRepair{i, KeyChange{keys[i], key}})
+		}
+		run = run[:0]
+		return nil
+	}
+	for i, key := range keys {
+		if !keep[i] {
+			run = append(run, i)
+			continue
+		}
+		if len(run) > 0 && !hasGap(d, prev, raw[i]) {
+			// No key fits before this one, so it is repaired along with the run and the next kept key is used
+			// as the neighbor instead.
+			report.Issues = append(report.Issues, AuditIssue{i, key, AuditNoRoom})
+			run = append(run, i)
+			continue
+		}
+		if err := flush(raw[i]); err != nil {
+			return AuditReport{}, err
+		}
+		prev = raw[i]
+	}
+	if err := flush(""); err != nil {
+		return AuditReport{}, err
+	}
+	sortAuditIssues(report.Issues)
+	return report, nil
+}
+
+// longestSortedKeys returns which valid keys form the longest strictly increasing subsequence of keys.
+func longestSortedKeys(keys []Key, valid []bool) []bool {
+	// tails[l] is the index of the smallest last key of increasing subsequences of length l+1.
+	var tails []int
+	prevIndex := make([]int, len(keys))
+	for i, key := range keys {
+		if !valid[i] {
+			continue
+		}
+		lo, hi := 0, len(tails)
+		for lo < hi {
+			mid := (lo + hi) / 2
+			if keys[tails[mid]] < key {
+				lo = mid + 1
+			} else {
+				hi = mid
+			}
+		}
+		prevIndex[i] = -1
+		if lo > 0 {
+			prevIndex[i] = tails[lo-1]
+		}
+		if lo == len(tails) {
+			tails = append(tails, i)
+		} else {
+			tails[lo] = i
+		}
+	}
+
+	keep := make([]bool, len(keys))
+	if len(tails) == 0 {
+		return keep
+	}
+	for i := tails[len(tails)-1]; i >= 0; i = prevIndex[i] {
+		keep[i] = true
+	}
+	return keep
+}
+
+func sortAuditIssues(issues []AuditIssue) {
+	slices.SortStableFunc(issues, func(a, b AuditIssue) int {
+		return cmp.Compare(a.Index, b.Index)
+	})
+}
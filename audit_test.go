@@ -0,0 +1,62 @@
+package lexorank
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGenerator_Audit(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	report, err := g.Audit([]Key{"1", "3", "2", "4", "4", "a", "6", ""})
+	noError(t, err)
+
+	wantIssues := []AuditIssue{
+		{1, "3", AuditOutOfOrder},
+		{4, "4", AuditDuplicateKey},
+		{5, "a", AuditInvalidKey},
+		{7, "", AuditInvalidKey},
+	}
+	if !reflect.DeepEqual(report.Issues, wantIssues) {
+		t.Fatalf("expected %v, got %v", wantIssues, report.Issues)
+	}
+	wantRepairs := []AuditRepair{
+		{1, KeyChange{"3", "15"}},
+		{4, KeyChange{"4", "47"}},
+		{5, KeyChange{"a", "53"}},
+		{7, KeyChange{"", "8"}},
+	}
+	if !reflect.DeepEqual(report.Repairs, wantRepairs) {
+		t.Fatalf("expected %v, got %v", wantRepairs, report.Repairs)
+	}
+
+	t.Run("no issues", func(t *testing.T) {
+		report, err := g.Audit([]Key{"1", "2", "3"})
+		noError(t, err)
+		if len(report.Issues) != 0 || len(report.Repairs) != 0 {
+			t.Fatalf("expected no issues, got %v", report)
+		}
+	})
+	t.Run("no room", func(t *testing.T) {
+		report, err := g.Audit([]Key{"5", "9", "50"})
+		noError(t, err)
+
+		wantIssues := []AuditIssue{
+			{1, "9", AuditOutOfOrder},
+			{2, "50", AuditNoRoom},
+		}
+		if !reflect.DeepEqual(report.Issues, wantIssues) {
+			t.Fatalf("expected %v, got %v", wantIssues, report.Issues)
+		}
+		wantRepairs := []AuditRepair{
+			{1, KeyChange{"9", "7"}},
+			{2, KeyChange{"50", "8"}},
+		}
+		if !reflect.DeepEqual(report.Repairs, wantRepairs) {
+			t.Fatalf("expected %v, got %v", wantRepairs, report.Repairs)
+		}
+	})
+}
@@ -0,0 +1,60 @@
+package lexorank
+
+import (
+	"context"
+	"fmt"
+	"iter"
+)
+
+// BulkLoad generates keys for items in order with the Rebalancer, such as the rows of a first-time backfill, and
+// calls save with each batch of items and their keys. The keys are the same as those of Rebalance, so the number
+// of items does not have to be known in advance. Items are consumed one at a time and at most one batch is kept in
+// memory. A channel can be loaded by ranging over it in items.
+//
+// The batch size and the progress function are set by WithBatchSize and WithProgress.
+// BulkLoad stops before the next save once ctx is done. If it fails, the returned progress tells which items have
+// already been saved.
+func BulkLoad[T any](ctx context.Context, r *Rebalancer, items iter.Seq[T], save func(ctx context.Context, items []T, keys []Key) error, opts ...RebalanceCoordinatorOption) (RebalanceProgress, error) {
+	c := NewRebalanceCoordinator(r, nil, nil, opts...)
+	if c.batchSize <= 0 {
+		return RebalanceProgress{}, withCode(CodeInvalidArgument, fmt.Errorf("batch size must be positive: %d", c.batchSize))
+	}
+
+	var progress RebalanceProgress
+	batch := make([]T, 0, c.batchSize)
+	keys := make([]Key, 0, c.batchSize)
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := save(ctx, batch, keys); err != nil {
+			return fmt.Errorf("failed to save batch after %q: %w", progress.LastKey, err)
+		}
+		progress.Batches++
+		progress.Keys += len(keys)
+		progress.LastKey = keys[len(keys)-1]
+		batch, keys = batch[:0], keys[:0]
+		c.progress(progress)
+		return nil
+	}
+
+	for item, key := range assignKeys(r, items) {
+		batch = append(batch, item)
+		keys = append(keys, key)
+		if len(batch) == c.batchSize {
+			if err := flush(); err != nil {
+				return progress, err
+			}
+		}
+	}
+	if err := r.Err(); err != nil {
+		return progress, err
+	}
+	if err := flush(); err != nil {
+		return progress, err
+	}
+	return progress, nil
+}
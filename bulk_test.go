@@ -0,0 +1,75 @@
+package lexorank
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestBulkLoad(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	r, err := NewRebalancer(NewGenerator(WithCharacterSet(charSet), WithInitial("500")))
+	noError(t, err)
+
+	items := slices.Values([]string{"a", "b", "c", "d", "e"})
+
+	t.Run("success", func(t *testing.T) {
+		var saved []string
+		var keys []Key
+		var progresses []RebalanceProgress
+		progress, err := BulkLoad(context.Background(), r, items, func(_ context.Context, batch []string, batchKeys []Key) error {
+			if len(batch) > 2 || len(batch) != len(batchKeys) {
+				t.Fatalf("unexpected batch: %v %v", batch, batchKeys)
+			}
+			saved = append(saved, batch...)
+			keys = append(keys, batchKeys...)
+			return nil
+		}, WithBatchSize(2), WithProgress(func(p RebalanceProgress) {
+			progresses = append(progresses, p)
+		}))
+		noError(t, err)
+
+		if want := (RebalanceProgress{3, 5, "540"}); progress != want {
+			t.Fatalf("expected %v, got %v", want, progress)
+		}
+		if len(progresses) != 3 || progresses[0] != (RebalanceProgress{1, 2, "510"}) {
+			t.Fatalf("unexpected progresses: %v", progresses)
+		}
+		if !slices.Equal(saved, []string{"a", "b", "c", "d", "e"}) || !slices.Equal(keys, []Key{"500", "510", "520", "530", "540"}) {
+			t.Fatalf("unexpected result: %v %v", saved, keys)
+		}
+	})
+
+	t.Run("error on save", func(t *testing.T) {
+		errSave := errors.New("save error")
+		n := 0
+		progress, err := BulkLoad(context.Background(), r, items, func(context.Context, []string, []Key) error {
+			n++
+			if n == 2 {
+				return errSave
+			}
+			return nil
+		}, WithBatchSize(2))
+		if !errors.Is(err, errSave) {
+			t.Fatalf("expected save error, got %v", err)
+		}
+		if want := (RebalanceProgress{1, 2, "510"}); progress != want {
+			t.Fatalf("expected %v, got %v", want, progress)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		_, err := BulkLoad(ctx, r, items, func(context.Context, []string, []Key) error {
+			t.Fatal("unexpected save")
+			return nil
+		})
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,145 @@
+package lexorank
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"unicode/utf8"
+)
+
+// ByteGenerator generates keys of raw bytes using all 256 byte values, for stores that compare keys as bytes,
+// such as RocksDB and Badger. It packs 8 bits into each byte of a key, the maximum density.
+type ByteGenerator struct {
+	generator *Generator
+	excluded  [256]bool
+}
+
+// ControlBytes are the ASCII control bytes, 0x00 to 0x1f and 0x7f.
+// Excluding them keeps keys safe for C-string APIs and text protocols.
+var ControlBytes = append([]byte{
+	0x00, 0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07, 0x08, 0x09, 0x0a, 0x0b, 0x0c, 0x0d, 0x0e, 0x0f,
+	0x10, 0x11, 0x12, 0x13, 0x14, 0x15, 0x16, 0x17, 0x18, 0x19, 0x1a, 0x1b, 0x1c, 0x1d, 0x1e, 0x1f,
+}, 0x7f)
+
+// NewByteGenerator creates a new ByteGenerator with the specified options.
+// It returns an error if fewer than two bytes remain after WithExcludedBytes.
+func NewByteGenerator(opts ...ByteGeneratorOption) (*ByteGenerator, error) {
+	g := &ByteGenerator{
+		nil,
+		[256]bool{},
+	}
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	var runes []rune
+	var runeToIndex [256]int
+	for i := range 256 {
+		if g.excluded[i] {
+			continue
+		}
+		runeToIndex[i] = len(runes)
+		runes = append(runes, rune(i))
+	}
+	if len(runes) < 2 {
+		return nil, withCode(CodeInvalidCharacterSet, fmt.Errorf("invalid excluded bytes: %d bytes remain, but at least 2 are required", len(runes)))
+	}
+	set := &characterSet{
+		runes,
+		runeToIndex,
+	}
+	g.generator = NewGenerator(WithCharacterSet(set), WithInitial(string(set.Mid(set.Min(), set.Max()))))
+	return g, nil
+}
+
+// Between generates a key that comes between the prevKey and nextKey keys in byte order.
+func (g *ByteGenerator) Between(prevKey, nextKey []byte) ([]byte, error) {
+	key, err := g.generator.Between(bytesToKey(prevKey), bytesToKey(nextKey))
+	if err != nil {
+		return nil, err
+	}
+	return keyToBytes(key), nil
+}
+
+// Next generates a key that comes after the given key.
+func (g *ByteGenerator) Next(key []byte) ([]byte, error) {
+	return g.Between(key, nil)
+}
+
+// Prev generates a key that comes before the given key.
+func (g *ByteGenerator) Prev(key []byte) ([]byte, error) {
+	return g.Between(nil, key)
+}
+
+// Initial generates the initial key for this generator.
+func (g *ByteGenerator) Initial() ([]byte, error) {
+	return g.Between(nil, nil)
+}
+
+// bytesToKey maps each byte to the rune of the same value.
+// UTF-8 preserves the order of runes, so the Key sorts in the same order as the bytes.
+func bytesToKey(b []byte) Key {
+	runes := make([]rune, len(b))
+	for i, c := range b {
+		runes[i] = rune(c)
+	}
+	return Key(runes)
+}
+
+func keyToBytes(key Key) []byte {
+	b := make([]byte, 0, len(key))
+	for _, r := range string(key) {
+		b = append(b, byte(r))
+	}
+	return b
+}
+
+type byteGeneratorOption func(*ByteGenerator)
+
+// ByteGeneratorOption is a option for configuring the ByteGenerator.
+type ByteGeneratorOption byteGeneratorOption
+
+// WithExcludedBytes returns a ByteGeneratorOption that excludes the bytes from generated keys,
+// such as 0x00 for C-string APIs or ControlBytes for text protocols.
+// At least two bytes must remain.
+func WithExcludedBytes(b ...byte) ByteGeneratorOption {
+	return func(g *ByteGenerator) {
+		for _, c := range b {
+			g.excluded[c] = true
+		}
+	}
+}
+
+// CompareBytes compares keys stored as raw bytes, such as values scanned from a database into []byte, without
+// converting them to Key. The result is the same as that of comparing the keys.
+func CompareBytes(a, b []byte) int {
+	return bytes.Compare(a, b)
+}
+
+// ValidateBytes checks if the key stored as raw bytes is not empty and consists of characters of the character set,
+// without converting it to Key. It does not allocate for valid keys of character sets created by this package.
+func ValidateBytes(set CharacterSet, key []byte) error {
+	if len(key) == 0 {
+		return withCode(CodeInvalidKey, errors.New("invalid key: key is empty"))
+	}
+	c, indexed := set.(*characterSet)
+	var runes []rune
+	if !indexed {
+		runes = characterSetRunes(set)
+	}
+	for i := 0; i < len(key); {
+		r, size := utf8.DecodeRune(key[i:])
+		var ok bool
+		if indexed {
+			ok = r >= 0 && int(r) < len(c.runeToIndex) && c.runes[c.runeToIndex[r]] == r
+		} else {
+			ok = slices.Contains(runes, r)
+		}
+		if !ok {
+			return withCode(CodeInvalidKey, fmt.Errorf("invalid key: '%c' is not in the character set: %q", r, key))
+		}
+		i += size
+	}
+	return nil
+}
@@ -0,0 +1,129 @@
+package lexorank
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+)
+
+func TestByteGenerator(t *testing.T) {
+	g, err := NewByteGenerator()
+	noError(t, err)
+
+	for _, tt := range []struct {
+		prev []byte
+		next []byte
+		want []byte
+	}{
+		{nil, nil, []byte{0x7f}},
+		{[]byte{0x7f}, nil, []byte{0x80}},
+		{nil, []byte{0x7f}, []byte{0x7e}},
+		{[]byte{0x00, 0x01}, []byte{0xff}, []byte{0x7f, 0x7f}},
+		{[]byte{0x10}, []byte{0x11}, []byte{0x10, 0x7f}},
+		{[]byte{0xff}, nil, []byte{0xff, 0x01}},
+	} {
+		t.Run(fmt.Sprintf("%x_%x", tt.prev, tt.next), func(t *testing.T) {
+			key, err := g.Between(tt.prev, tt.next)
+			noError(t, err)
+			if !bytes.Equal(key, tt.want) {
+				t.Fatalf("expected %x, got %x", tt.want, key)
+			}
+		})
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		var recursive func(prev, next []byte, depth int)
+		recursive = func(prev, next []byte, depth int) {
+			if depth == 0 {
+				return
+			}
+			key, err := g.Between(prev, next)
+			noError(t, err)
+			if bytes.Compare(key, prev) <= 0 || (next != nil && bytes.Compare(key, next) >= 0) {
+				t.Fatalf("%x-%x key %x is out of range", prev, next, key)
+			}
+			recursive(key, next, depth-1)
+			recursive(prev, key, depth-1)
+		}
+		recursive(nil, nil, 14)
+	})
+}
+
+func TestWithExcludedBytes(t *testing.T) {
+	g, err := NewByteGenerator(WithExcludedBytes(ControlBytes...))
+	noError(t, err)
+
+	for _, tt := range []struct {
+		prev []byte
+		next []byte
+		want []byte
+	}{
+		{nil, nil, []byte{0x90}},
+		{[]byte{0x7e}, nil, []byte{0x80}},
+		{nil, []byte{0x22}, []byte{0x21}},
+		{[]byte{0x20}, []byte{0x21}, []byte{0x20, 0x90}},
+		{[]byte{0x7e}, []byte{0x80}, []byte{0x7e, 0x90}},
+		{[]byte{0xff}, nil, []byte{0xff, 0x21}},
+	} {
+		t.Run(fmt.Sprintf("%x_%x", tt.prev, tt.next), func(t *testing.T) {
+			key, err := g.Between(tt.prev, tt.next)
+			noError(t, err)
+			if !bytes.Equal(key, tt.want) {
+				t.Fatalf("expected %x, got %x", tt.want, key)
+			}
+		})
+	}
+
+	t.Run("too few bytes remain", func(t *testing.T) {
+		all := make([]byte, 0, 256)
+		for i := range 256 {
+			all = append(all, byte(i))
+		}
+		for _, excluded := range [][]byte{all, all[1:]} {
+			if _, err := NewByteGenerator(WithExcludedBytes(excluded...)); ErrorCode(err) != CodeInvalidCharacterSet {
+				t.Fatalf("%d bytes excluded: expected %s, got %v", len(excluded), CodeInvalidCharacterSet, err)
+			}
+		}
+		_, err := NewByteGenerator(WithExcludedBytes(all[2:]...))
+		noError(t, err)
+	})
+}
+
+func TestCompareBytes(t *testing.T) {
+	for _, tt := range []struct {
+		a, b Key
+	}{
+		{"a", "b"},
+		{"a", "aa"},
+		{"Z", "a"},
+		{"", "0"},
+	} {
+		if CompareBytes([]byte(tt.a), []byte(tt.b)) != -1 || CompareBytes([]byte(tt.b), []byte(tt.a)) != 1 {
+			t.Errorf("expected %q < %q", tt.a, tt.b)
+		}
+	}
+	if CompareBytes([]byte("abc"), []byte("abc")) != 0 {
+		t.Error("expected equal keys")
+	}
+}
+
+func TestValidateBytes(t *testing.T) {
+	noError(t, ValidateBytes(DefaultCharacterSet, []byte("abcXYZ019")))
+	noError(t, ValidateBytes(runeCharacterSet{'a', 'é'}, []byte("aéa")))
+
+	for _, key := range []string{"", "ab-", "é", "\xff"} {
+		if err := ValidateBytes(DefaultCharacterSet, []byte(key)); ErrorCode(err) != CodeInvalidKey {
+			t.Errorf("%q: expected invalid key, got %v", key, err)
+		}
+	}
+	if err := ValidateBytes(runeCharacterSet{'a', 'é'}, []byte("b")); err == nil {
+		t.Error("expected error, but got nil")
+	}
+
+	key := []byte("UUUUUU")
+	if allocs := testing.AllocsPerRun(100, func() {
+		_ = ValidateBytes(DefaultCharacterSet, key)
+	}); allocs != 0 {
+		t.Fatalf("expected no allocation, got %v", allocs)
+	}
+}
@@ -0,0 +1,103 @@
+package lexorank
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf8"
+)
+
+// MarshalCBOR implements cbor.Marshaler of github.com/fxamacker/cbor, encoding the key as a text string.
+func (k Key) MarshalCBOR() ([]byte, error) {
+	return appendCBORText(nil, string(k)), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler of github.com/fxamacker/cbor.
+// It returns an error if data is not a text string or null, or is not valid UTF-8.
+func (k *Key) UnmarshalCBOR(data []byte) error {
+	s, err := readCBORText(data, "Key")
+	if err != nil {
+		return err
+	}
+	if !utf8.ValidString(s) {
+		return withCode(CodeInvalidKey, fmt.Errorf("invalid key %q: not valid UTF-8", s))
+	}
+	*k = Key(s)
+	return nil
+}
+
+// MarshalCBOR implements cbor.Marshaler of github.com/fxamacker/cbor, encoding the key as a text string.
+// It returns a *BucketKeyError if the key is not in the format "bucket|key".
+func (k BucketKey) MarshalCBOR() ([]byte, error) {
+	if err := k.validateFormat(); err != nil {
+		return nil, err
+	}
+	return appendCBORText(nil, string(k)), nil
+}
+
+// UnmarshalCBOR implements cbor.Unmarshaler of github.com/fxamacker/cbor.
+// It returns an error if data is not a text string or null, is not valid UTF-8, or is not in the format
+// "bucket|key".
+func (k *BucketKey) UnmarshalCBOR(data []byte) error {
+	s, err := readCBORText(data, "BucketKey")
+	if err != nil {
+		return err
+	}
+	if !utf8.ValidString(s) {
+		return withCode(CodeInvalidKey, fmt.Errorf("invalid bucket key %q: not valid UTF-8", s))
+	}
+	return k.set(s)
+}
+
+// cborText is the major type of text strings shifted into the initial byte.
+const cborText = 3 << 5
+
+// appendCBORText appends s as a definite-length text string of CBOR with the shortest header.
+func appendCBORText(b []byte, s string) []byte {
+	switch n := uint64(len(s)); {
+	case n < 24:
+		b = append(b, cborText|byte(n))
+	case n <= 0xff:
+		b = append(b, cborText|24, byte(n))
+	case n <= 0xffff:
+		b = binary.BigEndian.AppendUint16(append(b, cborText|25), uint16(n))
+	case n <= 0xffffffff:
+		b = binary.BigEndian.AppendUint32(append(b, cborText|26), uint32(n))
+	default:
+		b = binary.BigEndian.AppendUint64(append(b, cborText|27), n)
+	}
+	return append(b, s...)
+}
+
+// readCBORText reads a definite-length text or byte string of CBOR, or null as an empty string.
+func readCBORText(data []byte, name string) (string, error) {
+	invalid := withCode(CodeInvalidArgument, fmt.Errorf("cannot decode CBOR %x into %s", data, name))
+	if len(data) == 0 {
+		return "", invalid
+	}
+	if data[0] == 0xf6 && len(data) == 1 {
+		return "", nil
+	}
+	if major := data[0] >> 5; major != 2 && major != 3 {
+		return "", invalid
+	}
+	var n uint64
+	header := 1
+	switch info := data[0] & 0x1f; {
+	case info < 24:
+		n = uint64(info)
+	case info == 24 && len(data) >= 2:
+		n, header = uint64(data[1]), 2
+	case info == 25 && len(data) >= 3:
+		n, header = uint64(binary.BigEndian.Uint16(data[1:])), 3
+	case info == 26 && len(data) >= 5:
+		n, header = uint64(binary.BigEndian.Uint32(data[1:])), 5
+	case info == 27 && len(data) >= 9:
+		n, header = binary.BigEndian.Uint64(data[1:]), 9
+	default:
+		return "", invalid
+	}
+	if uint64(len(data)-header) != n {
+		return "", invalid
+	}
+	return string(data[header:]), nil
+}
@@ -0,0 +1,64 @@
+package lexorank
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestKey_CBOR(t *testing.T) {
+	for _, tt := range []struct {
+		key  Key
+		want []byte
+	}{
+		{"", []byte{0x60}},
+		{"abc", []byte{0x63, 'a', 'b', 'c'}},
+		{Key(strings.Repeat("a", 24)), append([]byte{0x78, 24}, strings.Repeat("a", 24)...)},
+		{Key(strings.Repeat("a", 256)), append([]byte{0x79, 0x01, 0x00}, strings.Repeat("a", 256)...)},
+	} {
+		data, err := tt.key.MarshalCBOR()
+		noError(t, err)
+		if !bytes.Equal(data, tt.want) {
+			t.Fatalf("%q: expected %x, got %x", tt.key, tt.want, data)
+		}
+		var k Key
+		noError(t, k.UnmarshalCBOR(data))
+		equalKey(t, k, tt.key)
+	}
+
+	var k Key
+	noError(t, k.UnmarshalCBOR([]byte{0x41, 'a'}))
+	equalKey(t, k, "a")
+	noError(t, k.UnmarshalCBOR([]byte{0xf6}))
+	equalKey(t, k, "")
+
+	for _, data := range [][]byte{nil, {0x01}, {0x63, 'a'}, {0x7f, 0x61, 'a', 0xff}, {0x61, 0xff}} {
+		if err := k.UnmarshalCBOR(data); err == nil {
+			t.Fatalf("%x: expected error, but got nil", data)
+		}
+	}
+}
+
+func TestBucketKey_CBOR(t *testing.T) {
+	data, err := BucketKey("0|abc").MarshalCBOR()
+	noError(t, err)
+	var k BucketKey
+	noError(t, k.UnmarshalCBOR(data))
+	equalBucketKey(t, k, "0|abc")
+
+	data, err = BucketKey("0:abc").MarshalCBOR()
+	noError(t, err)
+	noError(t, k.UnmarshalCBOR(data))
+	equalBucketKey(t, k, "0:abc")
+
+	if err := k.UnmarshalCBOR([]byte{0x63, '0', '|', 0xff}); err == nil {
+		t.Fatal("expected error for invalid UTF-8")
+	}
+	if _, err := BucketKey("abc").MarshalCBOR(); err == nil {
+		t.Fatal("expected error for invalid format")
+	}
+	if err := k.UnmarshalCBOR([]byte{0x63, 'a', 'b', 'c'}); err == nil {
+		t.Fatal("expected error for invalid format")
+	}
+	equalBucketKey(t, k, "0:abc")
+}
@@ -0,0 +1,53 @@
+package lexorank
+
+import (
+	"fmt"
+	"math/rand/v2"
+)
+
+// Collator compares strings under a locale-aware collation.
+// *collate.Collator of golang.org/x/text/collate satisfies it.
+type Collator interface {
+	CompareString(a, b string) int
+}
+
+// NewCollatedCharacterSet creates a new CharacterSet from a string of ASCII characters whose order under the
+// collator is the same as their byte order, so that keys sort identically under the collation and in Go.
+// It returns an error naming the characters that the collator orders differently, such as 'a' and 'B' under
+// most locales. Use VerifyCollation to check the order of whole keys as well.
+func NewCollatedCharacterSet(set string, c Collator) (CharacterSet, error) {
+	cs, err := NewASCIICharacterSet(set)
+	if err != nil {
+		return nil, err
+	}
+	runes := characterSetRunes(cs)
+	for i, a := range runes {
+		for _, b := range runes[i+1:] {
+			if c.CompareString(string(a), string(b)) >= 0 {
+				return nil, withCode(CodeInvalidCharacterSet, fmt.Errorf("invalid character set: '%c' does not sort before '%c' under the collation", a, b))
+			}
+		}
+	}
+	return cs, nil
+}
+
+// VerifyCollation checks that keys generated by the Generator sort in the same order under the collator as in Go,
+// using samples random key pairs and the keys generated between them.
+func VerifyCollation(g *Generator, c Collator, samples int, r *rand.Rand) error {
+	for range samples {
+		prev, next, err := RandomKeyPair(r, g.characterSet, 8)
+		if err != nil {
+			return err
+		}
+		key, err := g.Between(prev, next)
+		if err != nil {
+			return err
+		}
+		for _, pair := range [][2]Key{{prev, key}, {key, next}, {prev, next}} {
+			if c.CompareString(string(pair[0]), string(pair[1])) >= 0 {
+				return withCode(CodeInvalidCharacterSet, fmt.Errorf("%q does not sort before %q under the collation", pair[0], pair[1]))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package lexorank
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+// foldCollator compares strings case-insensitively, ignoring '-', which mimics typical locale collations.
+type foldCollator struct{}
+
+func (foldCollator) CompareString(a, b string) int {
+	normalize := func(s string) string {
+		return strings.ReplaceAll(strings.ToLower(s), "-", "")
+	}
+	return strings.Compare(normalize(a), normalize(b))
+}
+
+func TestNewCollatedCharacterSet(t *testing.T) {
+	_, err := NewCollatedCharacterSet("0123456789abcdefghijklmnopqrstuvwxyz", foldCollator{})
+	noError(t, err)
+
+	if _, err := NewCollatedCharacterSet("0123456789ABCabc", foldCollator{}); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+}
+
+func TestVerifyCollation(t *testing.T) {
+	r := rand.New(rand.NewPCG(1, 2))
+
+	charSet, err := NewCollatedCharacterSet("0123456789abcdefghijklmnopqrstuvwxyz", foldCollator{})
+	noError(t, err)
+	noError(t, VerifyCollation(NewGenerator(WithCharacterSet(charSet)), foldCollator{}, 1000, r))
+
+	if err := VerifyCollation(NewGenerator(), foldCollator{}, 1000, r); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+
+	// '-' is ignored by the collator, so the order of whole keys differs even though the characters are ordered.
+	charSet, err = NewASCIICharacterSet("-0123456789")
+	noError(t, err)
+	if err := VerifyCollation(NewGenerator(WithCharacterSet(charSet)), foldCollator{}, 1000, r); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+}
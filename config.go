@@ -0,0 +1,137 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// GeneratorConfig is the serializable configuration of a Generator, which can be shared between services to
+// construct identical Generators with NewGeneratorFromConfig. Zero values mean the defaults.
+// It can be written in JSON and YAML with the same field names.
+type GeneratorConfig struct {
+	// CharacterSet is the characters of the character set in order.
+	CharacterSet string `json:"character_set" yaml:"character_set"`
+	Initial      string `json:"initial" yaml:"initial"`
+	// Midpoint is the name of the MidpointStrategy: "centered", "ceil", "biased_left", "biased_right" or "random".
+	Midpoint string `json:"midpoint,omitempty" yaml:"midpoint,omitempty"`
+	// Algorithm is the name of the Algorithm: "heuristic", "fractional" or "mudder".
+	Algorithm         string  `json:"algorithm,omitempty" yaml:"algorithm,omitempty"`
+	DenseRatio        float64 `json:"dense_ratio,omitempty" yaml:"dense_ratio,omitempty"`
+	MinKeyLength      int     `json:"min_key_length,omitempty" yaml:"min_key_length,omitempty"`
+	CaseInsensitive   bool    `json:"case_insensitive,omitempty" yaml:"case_insensitive,omitempty"`
+	UniqueSuffix      int     `json:"unique_suffix,omitempty" yaml:"unique_suffix,omitempty"`
+	PinnedTop         Key     `json:"pinned_top,omitempty" yaml:"pinned_top,omitempty"`
+	PinnedBottom      Key     `json:"pinned_bottom,omitempty" yaml:"pinned_bottom,omitempty"`
+	AlternatingGrowth bool    `json:"alternating_growth,omitempty" yaml:"alternating_growth,omitempty"`
+	FixedWidth        int     `json:"fixed_width,omitempty" yaml:"fixed_width,omitempty"`
+	KeyPrefix         string  `json:"key_prefix,omitempty" yaml:"key_prefix,omitempty"`
+}
+
+var midpointStrategies = map[string]MidpointStrategy{
+	"centered":     CenteredMidpoint,
+	"ceil":         CeilMidpoint,
+	"biased_left":  BiasedLeftMidpoint,
+	"biased_right": BiasedRightMidpoint,
+	"random":       RandomMidpoint,
+}
+
+var algorithms = map[string]Algorithm{
+	"heuristic":  HeuristicAlgorithm,
+	"fractional": FractionalAlgorithm,
+	"mudder":     MudderAlgorithm,
+}
+
+// nameOf returns the name of v in m.
+func nameOf[V comparable](m map[string]V, v V) (string, bool) {
+	for name, value := range m {
+		if value == v {
+			return name, true
+		}
+	}
+	return "", false
+}
+
+// Config returns the configuration of the Generator. The source of randomness and the observer are not part of
+// the configuration. It returns an error if the Generator has a setting that cannot be serialized: a character
+// set that NewASCIICharacterSet cannot create, a custom MidpointStrategy or Algorithm, an HMAC secret or
+// a KeyTransformer.
+func (g *Generator) Config() (GeneratorConfig, error) {
+	set := string(characterSetRunes(g.characterSet))
+	rebuilt, err := NewASCIICharacterSet(set)
+	if err != nil || !slices.Equal(characterSetRunes(rebuilt), []rune(set)) {
+		return GeneratorConfig{}, withCode(CodeInvalidArgument, fmt.Errorf("character set %q is not serializable", set))
+	}
+	midpoint, ok := nameOf(midpointStrategies, g.midpoint)
+	if !ok {
+		return GeneratorConfig{}, withCode(CodeInvalidArgument, fmt.Errorf("midpoint strategy %T is not serializable", g.midpoint))
+	}
+	algorithm, ok := nameOf(algorithms, g.algorithm)
+	if !ok {
+		return GeneratorConfig{}, withCode(CodeInvalidArgument, fmt.Errorf("algorithm %T is not serializable", g.algorithm))
+	}
+	if g.hmacSecret != nil {
+		return GeneratorConfig{}, withCode(CodeInvalidArgument, errors.New("HMAC secret is not serializable"))
+	}
+	if g.transformer != nil {
+		return GeneratorConfig{}, withCode(CodeInvalidArgument, errors.New("key transformer is not serializable"))
+	}
+	return GeneratorConfig{
+		set,
+		g.initial,
+		midpoint,
+		algorithm,
+		g.denseRatio,
+		g.minLength,
+		g.foldCase,
+		g.suffixLength,
+		g.pinnedTop,
+		g.pinnedBottom,
+		g.growth != nil,
+		g.fixedWidth,
+		g.keyPrefix,
+	}, nil
+}
+
+// NewGeneratorFromConfig creates a new Generator with the configuration returned by Generator.Config.
+// The options are applied after the configuration, e.g. to set the source of randomness.
+func NewGeneratorFromConfig(c GeneratorConfig, opts ...GeneratorOption) (*Generator, error) {
+	var configOpts []GeneratorOption
+	if c.CharacterSet != "" {
+		set, err := NewASCIICharacterSet(c.CharacterSet)
+		if err != nil {
+			return nil, err
+		}
+		configOpts = append(configOpts, WithCharacterSet(set))
+	}
+	if c.Midpoint != "" {
+		strategy, ok := midpointStrategies[c.Midpoint]
+		if !ok {
+			return nil, withCode(CodeInvalidArgument, fmt.Errorf("unknown midpoint strategy %q", c.Midpoint))
+		}
+		configOpts = append(configOpts, WithMidpointStrategy(strategy))
+	}
+	if c.Algorithm != "" {
+		algorithm, ok := algorithms[c.Algorithm]
+		if !ok {
+			return nil, withCode(CodeInvalidArgument, fmt.Errorf("unknown algorithm %q", c.Algorithm))
+		}
+		configOpts = append(configOpts, WithAlgorithm(algorithm))
+	}
+	if c.AlternatingGrowth {
+		configOpts = append(configOpts, WithAlternatingGrowth())
+	}
+	configOpts = append(configOpts,
+		WithInitial(c.Initial),
+		WithDenseRatio(c.DenseRatio),
+		WithMinKeyLength(c.MinKeyLength),
+		WithUniqueSuffix(c.UniqueSuffix),
+		WithPinnedRanges(c.PinnedTop, c.PinnedBottom),
+		WithFixedWidth(c.FixedWidth),
+		WithKeyPrefix(c.KeyPrefix),
+	)
+	if c.CaseInsensitive {
+		configOpts = append(configOpts, WithCaseInsensitive())
+	}
+	return NewGenerator(append(configOpts, opts...)...), nil
+}
@@ -0,0 +1,81 @@
+package lexorank
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestGenerator_Config(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789abcdefghijklmnopqrstuvwxyz")
+	noError(t, err)
+
+	g := NewGenerator(
+		WithCharacterSet(charSet),
+		WithInitial("i"),
+		WithMidpointStrategy(BiasedLeftMidpoint),
+		WithMinKeyLength(3),
+		WithCaseInsensitive(),
+		WithKeyPrefix("rnk_"),
+	)
+	config, err := g.Config()
+	noError(t, err)
+
+	data, err := json.Marshal(config)
+	noError(t, err)
+	want := `{"character_set":"0123456789abcdefghijklmnopqrstuvwxyz","initial":"i","midpoint":"biased_left","algorithm":"heuristic","min_key_length":3,"case_insensitive":true,"key_prefix":"rnk_"}`
+	if string(data) != want {
+		t.Fatalf("expected %s, got %s", want, data)
+	}
+
+	var decoded GeneratorConfig
+	noError(t, json.Unmarshal(data, &decoded))
+	restored, err := NewGeneratorFromConfig(decoded)
+	noError(t, err)
+
+	for _, keys := range [][2]Key{{"", ""}, {"rnk_i00", ""}, {"", "rnk_i00"}, {"rnk_i00", "rnk_i01"}} {
+		want, err := g.Between(keys[0], keys[1])
+		noError(t, err)
+		got, err := restored.Between(keys[0], keys[1])
+		noError(t, err)
+		equalKey(t, got, want)
+	}
+
+	restoredConfig, err := restored.Config()
+	noError(t, err)
+	if restoredConfig != config {
+		t.Fatalf("expected %+v, got %+v", config, restoredConfig)
+	}
+
+	for _, g := range []*Generator{
+		NewGenerator(WithMidpointStrategy(nil)),
+		NewGenerator(WithAlgorithm(panicAlgorithm{})),
+		NewGenerator(WithHMAC([]byte("secret"), 4)),
+		NewGenerator(WithTransformer(prefixTransformer("t-"))),
+		NewGenerator(WithCharacterSet(runeCharacterSet{'a', 'é'})),
+	} {
+		if _, err := g.Config(); ErrorCode(err) != CodeInvalidArgument {
+			t.Fatalf("expected invalid argument, got %v", err)
+		}
+	}
+
+	for _, c := range []GeneratorConfig{
+		{Midpoint: "middle"},
+		{Algorithm: "exact"},
+		{CharacterSet: "aa"},
+	} {
+		if _, err := NewGeneratorFromConfig(c); err == nil {
+			t.Fatalf("%+v: expected error, but got nil", c)
+		}
+	}
+}
+
+func TestGeneratorConfig_Tags(t *testing.T) {
+	typ := reflect.TypeFor[GeneratorConfig]()
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		if f.Tag.Get("json") == "" || f.Tag.Get("yaml") != f.Tag.Get("json") {
+			t.Errorf("%s: json tag %q and yaml tag %q differ", f.Name, f.Tag.Get("json"), f.Tag.Get("yaml"))
+		}
+	}
+}
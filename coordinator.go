@@ -0,0 +1,149 @@
+package lexorank
+
+import (
+	"context"
+	"fmt"
+)
+
+// KeyChange is a change of a key from Old to New.
+type KeyChange struct {
+	Old Key
+	New Key
+}
+
+// LoadBatchFunc loads at most limit keys greater than after in ascending order.
+// after is empty for the first batch. Returning no keys ends the rebalance.
+type LoadBatchFunc func(ctx context.Context, after Key, limit int) ([]Key, error)
+
+// SaveBatchFunc persists a batch of key changes.
+type SaveBatchFunc func(ctx context.Context, changes []KeyChange) error
+
+// RebalanceProgress reports how far a rebalance has proceeded.
+type RebalanceProgress struct {
+	// Batches is the number of saved batches.
+	Batches int
+	// Keys is the number of rebalanced keys.
+	Keys int
+	// LastKey is the last old key that has been rebalanced, or the last new key saved by BulkLoad.
+	LastKey Key
+}
+
+// RebalanceCoordinator drives a rebalance against a live table through batch callbacks,
+// so that no more than the batch size of keys is loaded or saved at once.
+//
+// The new keys must not be visible to LoadBatch, for example by saving them to another column or bucket,
+// so that paging by the old keys is not affected by the saved batches.
+type RebalanceCoordinator struct {
+	rebalancer *Rebalancer
+	load       LoadBatchFunc
+	save       SaveBatchFunc
+	batchSize  int
+	progress   func(RebalanceProgress)
+}
+
+// NewRebalanceCoordinator creates a new RebalanceCoordinator with the specified options.
+func NewRebalanceCoordinator(r *Rebalancer, load LoadBatchFunc, save SaveBatchFunc, opts ...RebalanceCoordinatorOption) *RebalanceCoordinator {
+	c := &RebalanceCoordinator{
+		r,
+		load,
+		save,
+		1000,
+		func(RebalanceProgress) {},
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// Run rebalances all keys returned by LoadBatch and returns the final progress.
+// If it fails, the returned progress tells which keys have already been saved.
+// Run stops before the next load or save once ctx is done, and ctx is passed to LoadBatch and SaveBatch.
+// It returns an error if the batch size is not positive.
+func (c *RebalanceCoordinator) Run(ctx context.Context) (RebalanceProgress, error) {
+	if c.batchSize <= 0 {
+		return RebalanceProgress{}, withCode(CodeInvalidArgument, fmt.Errorf("batch size must be positive: %d", c.batchSize))
+	}
+	var progress RebalanceProgress
+	var loadErr error
+	keys := func(yield func(Key) bool) {
+		var after Key
+		for {
+			if err := ctx.Err(); err != nil {
+				loadErr = err
+				return
+			}
+			batch, err := c.load(ctx, after, c.batchSize)
+			if err != nil {
+				loadErr = fmt.Errorf("failed to load batch after %q: %w", after, err)
+				return
+			}
+			if len(batch) == 0 {
+				return
+			}
+			for _, key := range batch {
+				if !yield(key) {
+					return
+				}
+			}
+			after = batch[len(batch)-1]
+		}
+	}
+
+	changes := make([]KeyChange, 0, c.batchSize)
+	flush := func() error {
+		if len(changes) == 0 {
+			return nil
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := c.save(ctx, changes); err != nil {
+			return fmt.Errorf("failed to save batch after %q: %w", progress.LastKey, err)
+		}
+		progress.Batches++
+		progress.Keys += len(changes)
+		progress.LastKey = changes[len(changes)-1].Old
+		changes = changes[:0]
+		c.progress(progress)
+		return nil
+	}
+
+	for old, key := range c.rebalancer.Rebalance(keys) {
+		changes = append(changes, KeyChange{old, key})
+		if len(changes) == c.batchSize {
+			if err := flush(); err != nil {
+				return progress, err
+			}
+		}
+	}
+	if loadErr != nil {
+		return progress, loadErr
+	}
+	if err := c.rebalancer.Err(); err != nil {
+		return progress, err
+	}
+	if err := flush(); err != nil {
+		return progress, err
+	}
+	return progress, nil
+}
+
+type rebalanceCoordinatorOption func(*RebalanceCoordinator)
+
+// RebalanceCoordinatorOption is a option for configuring the RebalanceCoordinator.
+type RebalanceCoordinatorOption rebalanceCoordinatorOption
+
+// WithBatchSize returns a RebalanceCoordinatorOption that sets the max number of keys loaded and saved at once.
+func WithBatchSize(n int) RebalanceCoordinatorOption {
+	return func(c *RebalanceCoordinator) {
+		c.batchSize = n
+	}
+}
+
+// WithProgress returns a RebalanceCoordinatorOption that sets a function called after each saved batch.
+func WithProgress(f func(RebalanceProgress)) RebalanceCoordinatorOption {
+	return func(c *RebalanceCoordinator) {
+		c.progress = f
+	}
+}
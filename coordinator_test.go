@@ -0,0 +1,106 @@
+package lexorank
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestRebalanceCoordinator(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	r, err := NewRebalancer(NewGenerator(WithCharacterSet(charSet), WithInitial("500")))
+	noError(t, err)
+
+	table := []Key{"1", "15", "155", "1555", "2", "25", "3"}
+	load := func(_ context.Context, after Key, limit int) ([]Key, error) {
+		i, _ := slices.BinarySearch(table, after)
+		if i < len(table) && table[i] == after {
+			i++
+		}
+		return table[i:min(i+limit, len(table))], nil
+	}
+
+	t.Run("success", func(t *testing.T) {
+		var saved []KeyChange
+		var progresses []RebalanceProgress
+		c := NewRebalanceCoordinator(r, load, func(_ context.Context, changes []KeyChange) error {
+			if len(changes) > 3 {
+				t.Fatalf("expected at most 3 changes, got %d", len(changes))
+			}
+			saved = append(saved, changes...)
+			return nil
+		}, WithBatchSize(3), WithProgress(func(p RebalanceProgress) {
+			progresses = append(progresses, p)
+		}))
+
+		progress, err := c.Run(context.Background())
+		noError(t, err)
+
+		want := RebalanceProgress{3, 7, "3"}
+		if progress != want {
+			t.Fatalf("expected %v, got %v", want, progress)
+		}
+		if len(progresses) != 3 || progresses[0] != (RebalanceProgress{1, 3, "155"}) {
+			t.Fatalf("unexpected progresses: %v", progresses)
+		}
+		if len(saved) != len(table) || saved[6] != (KeyChange{"3", "560"}) {
+			t.Fatalf("unexpected changes: %v", saved)
+		}
+	})
+
+	t.Run("error on save", func(t *testing.T) {
+		errSave := errors.New("save error")
+		n := 0
+		c := NewRebalanceCoordinator(r, load, func(context.Context, []KeyChange) error {
+			n++
+			if n == 2 {
+				return errSave
+			}
+			return nil
+		}, WithBatchSize(3))
+
+		progress, err := c.Run(context.Background())
+		if !errors.Is(err, errSave) {
+			t.Fatalf("expected save error, got %v", err)
+		}
+		want := RebalanceProgress{1, 3, "155"}
+		if progress != want {
+			t.Fatalf("expected %v, got %v", want, progress)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		loads := 0
+		c := NewRebalanceCoordinator(r, func(ctx context.Context, after Key, limit int) ([]Key, error) {
+			loads++
+			return load(ctx, after, limit)
+		}, func(context.Context, []KeyChange) error {
+			cancel()
+			return nil
+		}, WithBatchSize(3))
+
+		progress, err := c.Run(ctx)
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+		if loads != 1 || progress.Keys != 3 {
+			t.Fatalf("expected to stop after the first batch, got %d loads and %v", loads, progress)
+		}
+	})
+
+	t.Run("error on invalid batch size", func(t *testing.T) {
+		for _, n := range []int{0, -1} {
+			c := NewRebalanceCoordinator(r, load, func(context.Context, []KeyChange) error {
+				t.Fatal("unexpected save")
+				return nil
+			}, WithBatchSize(n))
+			if _, err := c.Run(context.Background()); ErrorCode(err) != CodeInvalidArgument {
+				t.Fatalf("%d: expected %s, got %v", n, CodeInvalidArgument, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,156 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+const (
+	// DecimalRadixPoint is the radix point of the string of a Decimal, as in Jira ranks such as "0|hzzzzz:".
+	DecimalRadixPoint = ':'
+	// DecimalNegativeSign is the sign of negative Decimals.
+	DecimalNegativeSign = '-'
+)
+
+// Decimal is a fixed-point number whose digits are the characters of a CharacterSet, mirroring LexoDecimal of
+// Jira's LexoRank for porting code that manipulates ranks as numbers. For example, "i:i" is 18.5 with the
+// character set "0123456789abcdefghijklmnopqrstuvwxyz". Decimals are immutable.
+type Decimal struct {
+	digits keyDigits
+	value  *big.Int
+	scale  int
+}
+
+// ParseDecimal parses s as a Decimal of the character set, which must contain neither DecimalRadixPoint
+// nor DecimalNegativeSign.
+func ParseDecimal(set CharacterSet, s string) (Decimal, error) {
+	d := newKeyDigits(set)
+	if slices.Contains(d.runes, DecimalRadixPoint) || slices.Contains(d.runes, DecimalNegativeSign) {
+		return Decimal{}, withCode(CodeInvalidCharacterSet, fmt.Errorf("invalid character set: it contains '%c' or '%c'", DecimalRadixPoint, DecimalNegativeSign))
+	}
+	digits, negative := strings.CutPrefix(s, string(DecimalNegativeSign))
+	integer, fraction, _ := strings.Cut(digits, string(DecimalRadixPoint))
+	if integer == "" {
+		return Decimal{}, withCode(CodeInvalidKey, fmt.Errorf("invalid decimal %q: integer part is empty", s))
+	}
+	value, err := d.toInt(Key(integer+fraction), len([]rune(integer+fraction)))
+	if err != nil {
+		return Decimal{}, err
+	}
+	if negative {
+		value.Neg(value)
+	}
+	return newDecimal(d, value, len([]rune(fraction))), nil
+}
+
+// newDecimal creates a Decimal of value / base^scale without trailing zeros after the radix point.
+func newDecimal(d keyDigits, value *big.Int, scale int) Decimal {
+	digit := new(big.Int)
+	for scale > 0 {
+		quo, _ := new(big.Int).QuoRem(value, d.base, digit)
+		if digit.Sign() != 0 {
+			break
+		}
+		value = quo
+		scale--
+	}
+	return Decimal{d, value, scale}
+}
+
+// Scale returns the number of digits after the radix point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Sign returns -1, 0 or 1 depending on the sign of d.
+func (d Decimal) Sign() int {
+	return d.value.Sign()
+}
+
+// scaled returns the value of d multiplied to have the scale.
+func (d Decimal) scaled(scale int) *big.Int {
+	n := new(big.Int).Exp(d.digits.base, big.NewInt(int64(scale-d.scale)), nil)
+	return n.Mul(n, d.value)
+}
+
+// SetScale returns d with at most scale digits after the radix point. Extra digits are truncated, and if ceiling
+// is true and any digit is truncated, the last remaining digit is incremented.
+func (d Decimal) SetScale(scale int, ceiling bool) Decimal {
+	scale = max(scale, 0)
+	if scale >= d.scale {
+		return d
+	}
+	unit := new(big.Int).Exp(d.digits.base, big.NewInt(int64(d.scale-scale)), nil)
+	value := new(big.Int).Quo(d.value, unit)
+	if ceiling {
+		value.Add(value, big.NewInt(1))
+	}
+	return newDecimal(d.digits, value, scale)
+}
+
+// Cmp compares d and e and returns -1, 0 or 1.
+func (d Decimal) Cmp(e Decimal) int {
+	scale := max(d.scale, e.scale)
+	return d.scaled(scale).Cmp(e.scaled(scale))
+}
+
+// Add returns d + e.
+func (d Decimal) Add(e Decimal) Decimal {
+	scale := max(d.scale, e.scale)
+	return newDecimal(d.digits, new(big.Int).Add(d.scaled(scale), e.scaled(scale)), scale)
+}
+
+// Subtract returns d - e.
+func (d Decimal) Subtract(e Decimal) Decimal {
+	scale := max(d.scale, e.scale)
+	return newDecimal(d.digits, new(big.Int).Sub(d.scaled(scale), e.scaled(scale)), scale)
+}
+
+// Multiply returns d * e.
+func (d Decimal) Multiply(e Decimal) Decimal {
+	return newDecimal(d.digits, new(big.Int).Mul(d.value, e.value), d.scale+e.scale)
+}
+
+// DecimalBetween returns the Decimal of the smallest scale that is the midpoint of prev and next rounded down,
+// which is strictly between them. prev must be less than next.
+func DecimalBetween(prev, next Decimal) (Decimal, error) {
+	if prev.Cmp(next) >= 0 {
+		return Decimal{}, withCode(CodeOrderViolation, errors.New("prev decimal must be less than next decimal"))
+	}
+	for scale := max(prev.scale, next.scale); ; scale++ {
+		p := prev.scaled(scale)
+		mid := new(big.Int).Add(p, next.scaled(scale))
+		mid.Rsh(mid, 1)
+		if mid.Cmp(p) > 0 {
+			return newDecimal(prev.digits, mid, scale), nil
+		}
+	}
+}
+
+// String returns d in the format of ParseDecimal.
+func (d Decimal) String() string {
+	if d.value == nil {
+		return ""
+	}
+	abs := new(big.Int).Abs(d.value)
+	unit := new(big.Int).Exp(d.digits.base, big.NewInt(int64(d.scale)), nil)
+	integer, fraction := new(big.Int).QuoRem(abs, unit, new(big.Int))
+
+	var sb strings.Builder
+	if d.value.Sign() < 0 {
+		sb.WriteRune(DecimalNegativeSign)
+	}
+	length := 1
+	for n := new(big.Int).Set(d.digits.base); n.Cmp(integer) <= 0; n.Mul(n, d.digits.base) {
+		length++
+	}
+	sb.WriteString(string(d.digits.fromInt(integer, length)))
+	if d.scale > 0 {
+		sb.WriteRune(DecimalRadixPoint)
+		sb.WriteString(string(d.digits.fromInt(fraction, d.scale)))
+	}
+	return sb.String()
+}
@@ -0,0 +1,54 @@
+package lexorank
+
+import "testing"
+
+func TestDecimal(t *testing.T) {
+	base36, err := NewASCIICharacterSet("0123456789abcdefghijklmnopqrstuvwxyz")
+	noError(t, err)
+
+	parse := func(s string) Decimal {
+		t.Helper()
+		d, err := ParseDecimal(base36, s)
+		noError(t, err)
+		return d
+	}
+	equal := func(d Decimal, want string) {
+		t.Helper()
+		if d.String() != want {
+			t.Fatalf("expected %q, got %q", want, d.String())
+		}
+	}
+
+	equal(parse("hzzzzz"), "hzzzzz")
+	equal(parse("00i:i0"), "i:i")
+	equal(parse("-1:0"), "-1")
+	equal(parse("z").Add(parse("1")), "10")
+	equal(parse("0:i").Add(parse("0:i")), "1")
+	equal(parse("1").Subtract(parse("2")), "-1")
+	equal(parse("i").Multiply(parse("0:i")), "9")
+	if parse("a").Cmp(parse("9:z")) != 1 || parse("-1").Sign() != -1 {
+		t.Fatal("unexpected comparison")
+	}
+
+	mid, err := DecimalBetween(parse("1"), parse("3"))
+	noError(t, err)
+	equal(mid, "2")
+
+	mid, err = DecimalBetween(parse("1"), parse("2"))
+	noError(t, err)
+	equal(mid, "1:i")
+
+	mid, err = DecimalBetween(parse("hzzzzz"), parse("i00000"))
+	noError(t, err)
+	equal(mid, "hzzzzz:i")
+
+	if _, err := DecimalBetween(parse("2"), parse("1")); ErrorCode(err) != CodeOrderViolation {
+		t.Fatalf("expected order violation, got %v", err)
+	}
+	if _, err := ParseDecimal(base36, ":1"); err == nil {
+		t.Fatal("expected error for empty integer part")
+	}
+	if _, err := ParseDecimal(URLSafeCharacterSet, "1"); err == nil {
+		t.Fatal("expected error for a character set containing the sign")
+	}
+}
@@ -0,0 +1,64 @@
+package lexorank
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Density describes how full the range between two keys is.
+type Density struct {
+	// Length is the key length the capacity is measured for, which is the longest key observed in the range.
+	Length int
+	// Capacity is the number of keys of at most Length characters that fit in the range.
+	Capacity *big.Int
+	// Used is the number of keys observed in the range.
+	Used int
+	// Remaining is the number of keys that can still be inserted before keys grow longer than Length.
+	Remaining *big.Int
+}
+
+// Ratio returns the used fraction of the capacity in [0, 1].
+func (d Density) Ratio() float64 {
+	if d.Capacity.Sign() == 0 {
+		return 1
+	}
+	r, _ := new(big.Rat).SetFrac(big.NewInt(int64(d.Used)), d.Capacity).Float64()
+	return min(r, 1)
+}
+
+// Density estimates how full the range between prevKey and nextKey is, given the keys observed inside it.
+// An empty prevKey or nextKey means the start or the end of the keyspace.
+func (g *Generator) Density(prevKey, nextKey Key, keys []Key) (Density, error) {
+	if g.keyPrefix != "" {
+		unprefixed, stripped, err := g.unprefixed(append([]Key{prevKey, nextKey}, keys...))
+		if err != nil {
+			return Density{}, err
+		}
+		return unprefixed.Density(stripped[0], stripped[1], stripped[2:])
+	}
+	if nextKey != "" && prevKey >= nextKey {
+		return Density{}, withCode(CodeOrderViolation, fmt.Errorf("prevKey (%q) must be strictly less than nextKey (%q)", prevKey, nextKey))
+	}
+	length := 1
+	for _, key := range keys {
+		if key <= prevKey || (nextKey != "" && key >= nextKey) {
+			return Density{}, withCode(CodeInvalidArgument, fmt.Errorf("key (%q) must be between prevKey (%q) and nextKey (%q)", key, prevKey, nextKey))
+		}
+		length = max(length, len([]rune(key)))
+	}
+
+	d := newKeyDigits(g.characterSet)
+	lo, hi, err := keyRangeWithLength(d, prevKey, nextKey, length)
+	if err != nil {
+		return Density{}, err
+	}
+	capacity := new(big.Int)
+	if lo.Cmp(hi) <= 0 {
+		capacity.Sub(hi, lo).Add(capacity, big.NewInt(1))
+	}
+	remaining := new(big.Int).Sub(capacity, big.NewInt(int64(len(keys))))
+	if remaining.Sign() < 0 {
+		remaining.SetInt64(0)
+	}
+	return Density{length, capacity, len(keys), remaining}, nil
+}
@@ -0,0 +1,44 @@
+package lexorank
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGenerator_Density(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	for _, tt := range []struct {
+		prev      Key
+		next      Key
+		keys      []Key
+		length    int
+		capacity  int64
+		remaining int64
+		ratio     float64
+	}{
+		{"", "", nil, 1, 9, 9, 0},
+		{"", "", []Key{"5"}, 1, 9, 8, 1.0 / 9},
+		{"5", "6", []Key{"51", "52", "53", "54", "55"}, 2, 9, 4, 5.0 / 9},
+		{"5", "6", []Key{"51", "52", "53", "54", "55", "56", "57", "58", "59", "595"}, 3, 99, 89, 10.0 / 99},
+		{"59", "6", nil, 1, 0, 0, 1},
+	} {
+		t.Run(fmt.Sprintf("%s_%s_%v", tt.prev, tt.next, tt.keys), func(t *testing.T) {
+			d, err := g.Density(tt.prev, tt.next, tt.keys)
+			noError(t, err)
+			if d.Length != tt.length || d.Capacity.Int64() != tt.capacity || d.Remaining.Int64() != tt.remaining || d.Ratio() != tt.ratio {
+				t.Fatalf("expected %d %d %d %v, got %d %d %d %v",
+					tt.length, tt.capacity, tt.remaining, tt.ratio, d.Length, d.Capacity, d.Remaining, d.Ratio())
+			}
+		})
+	}
+
+	t.Run("error on key out of range", func(t *testing.T) {
+		if _, err := g.Density("5", "6", []Key{"7"}); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
@@ -0,0 +1,78 @@
+package lexorank
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// keyDigits interprets keys as fixed-length numbers whose digits are the characters of a character set.
+type keyDigits struct {
+	runes []rune
+	index map[rune]int
+	base  *big.Int
+}
+
+func newKeyDigits(set CharacterSet) keyDigits {
+	runes := characterSetRunes(set)
+	index := make(map[rune]int, len(runes))
+	for i, r := range runes {
+		index[r] = i
+	}
+	return keyDigits{runes, index, big.NewInt(int64(len(runes)))}
+}
+
+// toInt returns the number represented by the first length characters of key.
+// Keys shorter than length are padded with the min character.
+func (d keyDigits) toInt(key Key, length int) (*big.Int, error) {
+	n := new(big.Int)
+	runes := []rune(key)
+	for i := 0; i < length; i++ {
+		digit := 0
+		if i < len(runes) {
+			var ok bool
+			digit, ok = d.index[runes[i]]
+			if !ok {
+				return nil, withCode(CodeInvalidKey, fmt.Errorf("invalid key: '%c' is not in the character set: %q", runes[i], key))
+			}
+		}
+		n.Mul(n, d.base)
+		n.Add(n, big.NewInt(int64(digit)))
+	}
+	return n, nil
+}
+
+// fromInt returns the key of length characters representing n.
+func (d keyDigits) fromInt(n *big.Int, length int) Key {
+	runes := make([]rune, length)
+	n = new(big.Int).Set(n)
+	digit := new(big.Int)
+	for i := length - 1; i >= 0; i-- {
+		n.DivMod(n, d.base, digit)
+		runes[i] = d.runes[digit.Int64()]
+	}
+	return Key(runes)
+}
+
+// max returns the largest number representable with length characters.
+func (d keyDigits) max(length int) *big.Int {
+	n := new(big.Int).Exp(d.base, big.NewInt(int64(length)), nil)
+	return n.Sub(n, big.NewInt(1))
+}
+
+// toRat returns the fraction in [0, 1) represented by key, where each character is a digit after the radix point.
+func (d keyDigits) toRat(key Key) (*big.Rat, error) {
+	length := len([]rune(key))
+	num, err := d.toInt(key, length)
+	if err != nil {
+		return nil, err
+	}
+	denom := new(big.Int).Exp(d.base, big.NewInt(int64(length)), nil)
+	return new(big.Rat).SetFrac(num, denom), nil
+}
+
+// truncate returns the digits of the fraction r truncated to length characters.
+func (d keyDigits) truncate(r *big.Rat, length int) *big.Int {
+	n := new(big.Int).Exp(d.base, big.NewInt(int64(length)), nil)
+	n.Mul(n, r.Num())
+	return n.Quo(n, r.Denom())
+}
@@ -0,0 +1,111 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// DefaultSeparator is the separator of BucketKey used by NewBucket.
+const DefaultSeparator = '|'
+
+// MarshalText implements encoding.TextMarshaler.
+func (k Key) MarshalText() ([]byte, error) {
+	return []byte(k), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (k *Key) UnmarshalText(text []byte) error {
+	*k = Key(text)
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+func (k Key) MarshalBinary() ([]byte, error) {
+	return []byte(k), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It returns an error with CodeInvalidKey if data is empty or not valid UTF-8.
+func (k *Key) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return withCode(CodeInvalidKey, errors.New("invalid key: empty"))
+	}
+	if !utf8.Valid(data) {
+		return withCode(CodeInvalidKey, fmt.Errorf("invalid key %q: not valid UTF-8", data))
+	}
+	*k = Key(data)
+	return nil
+}
+
+// validateFormat checks if the BucketKey is in the format "bucket|key": a separator between a non-empty bucket
+// label and a non-empty rank. As BucketKey does not know its Bucket, the separator is taken to be the first run of
+// characters that are neither letters nor digits, so that keys with any such separator, e.g. ':' or "::", are
+// accepted. Use BucketKey.Validate to check the key against the configuration of a Bucket.
+// An empty BucketKey is valid.
+func (k BucketKey) validateFormat() error {
+	if k == "" {
+		return nil
+	}
+	isSeparator := func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+	i := strings.IndexFunc(string(k), isSeparator)
+	if i < 0 {
+		return &BucketKeyError{k, BucketKeySeparator, errors.New("not found")}
+	}
+	if i == 0 {
+		return &BucketKeyError{k, BucketKeyLabel, errors.New("is empty")}
+	}
+	rank := strings.TrimLeftFunc(string(k[i:]), isSeparator)
+	if rank == "" {
+		return &BucketKeyError{k, BucketKeyRank, errors.New("is empty")}
+	}
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler.
+// It returns a *BucketKeyError if the key is not in the format "bucket|key".
+func (k BucketKey) MarshalText() ([]byte, error) {
+	if err := k.validateFormat(); err != nil {
+		return nil, err
+	}
+	return []byte(k), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+// It returns a *BucketKeyError if the key is not in the format "bucket|key".
+func (k *BucketKey) UnmarshalText(text []byte) error {
+	return k.set(string(text))
+}
+
+func (k *BucketKey) set(s string) error {
+	key := BucketKey(s)
+	if err := key.validateFormat(); err != nil {
+		return err
+	}
+	*k = key
+	return nil
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler.
+// It returns a *BucketKeyError if the key is not in the format "bucket|key".
+func (k BucketKey) MarshalBinary() ([]byte, error) {
+	if err := k.validateFormat(); err != nil {
+		return nil, err
+	}
+	return []byte(k), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.
+// It returns an error with CodeInvalidKey if data is empty, is not valid UTF-8, or is not in the format
+// "bucket|key".
+func (k *BucketKey) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return withCode(CodeInvalidKey, errors.New("invalid bucket key: empty"))
+	}
+	if !utf8.Valid(data) {
+		return withCode(CodeInvalidKey, fmt.Errorf("invalid bucket key %q: not valid UTF-8", data))
+	}
+	return k.set(string(data))
+}
@@ -0,0 +1,45 @@
+package lexorank
+
+import (
+	"bytes"
+	"encoding/gob"
+	"testing"
+)
+
+func TestKey_BinaryEncoding(t *testing.T) {
+	var buf bytes.Buffer
+	noError(t, gob.NewEncoder(&buf).Encode(Key("abc")))
+	var k Key
+	noError(t, gob.NewDecoder(&buf).Decode(&k))
+	equalKey(t, k, "abc")
+
+	for _, data := range [][]byte{nil, {0xff}} {
+		if err := k.UnmarshalBinary(data); ErrorCode(err) != CodeInvalidKey {
+			t.Fatalf("%q: expected CodeInvalidKey, got %v", data, err)
+		}
+	}
+	equalKey(t, k, "abc")
+}
+
+func TestBucketKey_BinaryEncoding(t *testing.T) {
+	var buf bytes.Buffer
+	noError(t, gob.NewEncoder(&buf).Encode(BucketKey("0|abc")))
+	var k BucketKey
+	noError(t, gob.NewDecoder(&buf).Decode(&k))
+	equalBucketKey(t, k, "0|abc")
+
+	data, err := BucketKey("0:abc").MarshalBinary()
+	noError(t, err)
+	noError(t, k.UnmarshalBinary(data))
+	equalBucketKey(t, k, "0:abc")
+
+	for _, data := range [][]byte{nil, {'0', '|', 0xff}, []byte("abc"), []byte("|abc"), []byte("0|")} {
+		if err := k.UnmarshalBinary(data); ErrorCode(err) != CodeInvalidKey {
+			t.Fatalf("%q: expected CodeInvalidKey, got %v", data, err)
+		}
+	}
+	if _, err := BucketKey("abc").MarshalBinary(); ErrorCode(err) != CodeInvalidKey {
+		t.Fatalf("expected CodeInvalidKey, got %v", err)
+	}
+	equalBucketKey(t, k, "0:abc")
+}
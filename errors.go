@@ -0,0 +1,148 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Codes returned by ErrorCode. They are stable and can be exposed in API error bodies.
+const (
+	// CodeUnknown is the code of errors not from this package.
+	CodeUnknown = "UNKNOWN"
+	// CodeInvalidArgument is the code of errors caused by invalid arguments or options.
+	CodeInvalidArgument = "INVALID_ARGUMENT"
+	// CodeInvalidKey is the code of errors caused by malformed keys.
+	CodeInvalidKey = "INVALID_KEY"
+	// CodeInvalidCharacterSet is the code of errors caused by character sets not satisfying a requirement.
+	CodeInvalidCharacterSet = "INVALID_CHARACTER_SET"
+	// CodeOrderViolation is the code of errors caused by a prevKey not less than the nextKey.
+	CodeOrderViolation = "ORDER_VIOLATION"
+	// CodeKeySpaceExhausted is the code of errors returned when no key can be generated at the position.
+	CodeKeySpaceExhausted = "KEY_SPACE_EXHAUSTED"
+	// CodeKeySpaceDense is the code of ErrKeySpaceDense.
+	CodeKeySpaceDense = "KEY_SPACE_DENSE"
+	// CodeCaseCollision is the code of ErrCaseCollision.
+	CodeCaseCollision = "CASE_COLLISION"
+	// CodeBucketMismatch is the code of ErrBucketMismatch.
+	CodeBucketMismatch = "BUCKET_MISMATCH"
+	// CodeTenantMismatch is the code of ErrTenantMismatch.
+	CodeTenantMismatch = "TENANT_MISMATCH"
+	// CodeConflict is the code of ErrConflict.
+	CodeConflict = "CONFLICT"
+	// CodeInvalidSignature is the code of ErrInvalidSignature.
+	CodeInvalidSignature = "INVALID_SIGNATURE"
+	// CodeGapExhausted is the code of ErrGapExhausted.
+	CodeGapExhausted = "GAP_EXHAUSTED"
+	// CodePrecisionExhausted is the code of ErrPrecisionExhausted.
+	CodePrecisionExhausted = "PRECISION_EXHAUSTED"
+	// CodeVersionMismatch is the code of ErrVersionMismatch.
+	CodeVersionMismatch = "VERSION_MISMATCH"
+	// CodeInvariantViolation is the code of InvariantError.
+	CodeInvariantViolation = "INVARIANT_VIOLATION"
+)
+
+// ErrorCode returns the code of the first error in the tree of err that has a Code() string method,
+// or CodeUnknown if there is none. Errors returned by this package have codes.
+func ErrorCode(err error) string {
+	var coded interface{ Code() string }
+	if errors.As(err, &coded) {
+		return coded.Code()
+	}
+	return CodeUnknown
+}
+
+// codedError attaches a code to an error without changing its message.
+type codedError struct {
+	code string
+	err  error
+}
+
+func withCode(code string, err error) error {
+	return &codedError{code, err}
+}
+
+func (e *codedError) Error() string {
+	return e.err.Error()
+}
+
+func (e *codedError) Unwrap() error {
+	return e.err
+}
+
+// Code returns the code of the error.
+func (e *codedError) Code() string {
+	return e.code
+}
+
+// BetweenError is the error returned by Generator.Between, carrying the inputs that caused the error.
+type BetweenError struct {
+	Prev Key
+	Next Key
+	// CharacterSet is the characters of the character set in order.
+	CharacterSet string
+	// Reason is the cause of the error.
+	Reason error
+}
+
+// Error returns the message of the Reason, which already mentions the keys.
+func (e *BetweenError) Error() string {
+	return e.Reason.Error()
+}
+
+func (e *BetweenError) Unwrap() error {
+	return e.Reason
+}
+
+// Code returns the code of the Reason.
+func (e *BetweenError) Code() string {
+	return ErrorCode(e.Reason)
+}
+
+// BucketKeyPart is a part of a BucketKey.
+type BucketKeyPart string
+
+const (
+	// BucketKeyLabel is the bucket label before the separator.
+	BucketKeyLabel BucketKeyPart = "label"
+	// BucketKeySeparator is the separator between the label and the rank.
+	BucketKeySeparator BucketKeyPart = "separator"
+	// BucketKeyRank is the key after the separator.
+	BucketKeyRank BucketKeyPart = "rank"
+)
+
+// BucketKeyError is the error returned by BucketKey.Validate, telling which part of the key is invalid.
+type BucketKeyError struct {
+	Key  BucketKey
+	Part BucketKeyPart
+	// Reason is the cause of the error.
+	Reason error
+}
+
+func (e *BucketKeyError) Error() string {
+	return fmt.Sprintf("invalid %s of bucket key %q: %v", e.Part, e.Key, e.Reason)
+}
+
+func (e *BucketKeyError) Unwrap() error {
+	return e.Reason
+}
+
+// Code returns CodeInvalidKey.
+func (e *BucketKeyError) Code() string {
+	return CodeInvalidKey
+}
+
+// InvariantError is returned instead of panicking when an internal invariant is violated,
+// e.g. by a misconfigured option or a custom implementation of an interface.
+type InvariantError struct {
+	// Value is the value the violation panicked with.
+	Value any
+}
+
+func (e *InvariantError) Error() string {
+	return fmt.Sprintf("invariant violation: %v", e.Value)
+}
+
+// Code returns CodeInvariantViolation.
+func (e *InvariantError) Code() string {
+	return CodeInvariantViolation
+}
@@ -0,0 +1,96 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestErrorCode(t *testing.T) {
+	g := NewGenerator()
+	_, orderErr := g.Between("b", "a")
+	_, keyErr := KeyToRat(DefaultCharacterSet, "-")
+	_, setErr := NewASCIICharacterSet("aé")
+	_, bucketErr := NewBucket().Between("0|a", "1|b")
+
+	for _, tt := range []struct {
+		err  error
+		want string
+	}{
+		{orderErr, CodeOrderViolation},
+		{keyErr, CodeInvalidKey},
+		{setErr, CodeInvalidCharacterSet},
+		{bucketErr, CodeBucketMismatch},
+		{fmt.Errorf("wrapped: %w", ErrConflict), CodeConflict},
+		{errors.New("other"), CodeUnknown},
+		{nil, CodeUnknown},
+	} {
+		if got := ErrorCode(tt.err); got != tt.want {
+			t.Errorf("%v: expected %s, got %s", tt.err, tt.want, got)
+		}
+	}
+
+	if !errors.Is(bucketErr, ErrBucketMismatch) {
+		t.Fatal("expected the sentinel error to be kept")
+	}
+	if ErrBucketMismatch.Error() != "bucket mismatch" {
+		t.Fatalf("unexpected message: %s", ErrBucketMismatch)
+	}
+}
+
+func TestBetweenError(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	_, err = NewGenerator(WithCharacterSet(charSet)).Between("5", "4")
+	var betweenErr *BetweenError
+	if !errors.As(err, &betweenErr) {
+		t.Fatalf("expected *BetweenError, got %T", err)
+	}
+	if betweenErr.Prev != "5" || betweenErr.Next != "4" || betweenErr.CharacterSet != "0123456789" {
+		t.Fatalf("unexpected fields: %+v", betweenErr)
+	}
+	if ErrorCode(err) != CodeOrderViolation || err.Error() != betweenErr.Reason.Error() {
+		t.Fatalf("unexpected error: %s %v", ErrorCode(err), err)
+	}
+
+	_, err = NewBucket().Between("0|5", "1|4")
+	if !errors.Is(err, ErrBucketMismatch) {
+		t.Fatalf("expected ErrBucketMismatch, got %v", err)
+	}
+}
+
+type panicAlgorithm struct{}
+
+func (panicAlgorithm) Between(*Generator, Key, Key) (Key, error) {
+	panic("broken algorithm")
+}
+
+func TestInvariantError(t *testing.T) {
+	for _, g := range []*Generator{
+		NewGenerator(WithAlgorithm(panicAlgorithm{})),
+		NewGenerator(WithMidpointStrategy(nil)),
+		NewGenerator(WithAlgorithm(nil)),
+	} {
+		_, err := g.Between("1", "2")
+		var invariantErr *InvariantError
+		if !errors.As(err, &invariantErr) {
+			t.Fatalf("expected *InvariantError, got %v", err)
+		}
+		if ErrorCode(err) != CodeInvariantViolation {
+			t.Fatalf("unexpected code: %s", ErrorCode(err))
+		}
+	}
+
+	pinned := NewGenerator(WithAlgorithm(panicAlgorithm{}), WithPinnedRanges("1", "y"))
+	for _, pin := range []func(prevKey, nextKey Key) (Key, error){pinned.PinTop, pinned.PinBottom} {
+		if _, err := pin("0", "z"); ErrorCode(err) != CodeInvariantViolation {
+			t.Fatalf("expected %s, got %v", CodeInvariantViolation, err)
+		}
+	}
+
+	g := NewGenerator(WithCharacterSet(nil))
+	if g.CharacterSet() != DefaultCharacterSet {
+		t.Fatal("expected nil character set to keep the default")
+	}
+}
@@ -0,0 +1,113 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+)
+
+// WriteKeysCSV writes the keys with their positions in [0, 1) and lengths to w as CSV with the header
+// "key,position,length". The position is the one returned by Key.Float64.
+func WriteKeysCSV(w io.Writer, set CharacterSet, keys []Key) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"key", "position", "length"}); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		position, err := key.Float64(set)
+		if err != nil {
+			return err
+		}
+		record := []string{
+			string(key),
+			strconv.FormatFloat(position, 'g', -1, 64),
+			strconv.Itoa(len([]rune(key))),
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteKeysSVG writes an SVG image of width x height pixels to w, which draws a bar for each key at its position
+// on the horizontal axis from 0 to 1, whose height is proportional to the length of the key.
+// Clusters of tall bars show where keys are growing long.
+func WriteKeysSVG(w io.Writer, set CharacterSet, keys []Key, width, height int) error {
+	if width <= 0 || height <= 0 {
+		return withCode(CodeInvalidArgument, fmt.Errorf("width and height must be positive: %dx%d", width, height))
+	}
+	maxLength := 1
+	for _, key := range keys {
+		maxLength = max(maxLength, len([]rune(key)))
+	}
+	if _, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, height, width, height); err != nil {
+		return err
+	}
+	for _, key := range keys {
+		position, err := key.Float64(set)
+		if err != nil {
+			return err
+		}
+		x := position * float64(width)
+		h := float64(len([]rune(key))) / float64(maxLength) * float64(height)
+		if _, err := fmt.Fprintf(w, `<line x1="%.2f" y1="%d" x2="%.2f" y2="%.2f" stroke="black"><title>`, x, height, x, float64(height)-h); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(w, []byte(key)); err != nil {
+			return err
+		}
+		if _, err := io.WriteString(w, "</title></line>\n"); err != nil {
+			return err
+		}
+	}
+	_, err := io.WriteString(w, "</svg>\n")
+	return err
+}
+
+// MigrateCSV reads CSV records from r, whose first record is the header, and writes them to w in the same order
+// with a "key" column appended. The keys are assigned by PositionsToKeys to the numbers in the column named column,
+// such as a position of a spreadsheet or a legacy ordering column.
+func MigrateCSV(w io.Writer, r io.Reader, g *Generator, column string) error {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return err
+	}
+	if len(records) == 0 {
+		return withCode(CodeInvalidArgument, errors.New("no header"))
+	}
+	index := slices.Index(records[0], column)
+	if index < 0 {
+		return withCode(CodeInvalidArgument, fmt.Errorf("column %q not found", column))
+	}
+	positions := make([]float64, len(records)-1)
+	for i, record := range records[1:] {
+		positions[i], err = strconv.ParseFloat(record[index], 64)
+		if err != nil {
+			return withCode(CodeInvalidArgument, fmt.Errorf("invalid position at line %d: %w", i+2, err))
+		}
+	}
+	keys, err := PositionsToKeys(g, positions)
+	if err != nil {
+		return err
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write(append(records[0], "key")); err != nil {
+		return err
+	}
+	for i, record := range records[1:] {
+		if err := cw.Write(append(record, string(keys[i]))); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
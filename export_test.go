@@ -0,0 +1,61 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWriteKeysCSV(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	var sb strings.Builder
+	noError(t, WriteKeysCSV(&sb, charSet, []Key{"25", "5", "75"}))
+	want := "key,position,length\n25,0.25,2\n5,0.5,1\n75,0.75,2\n"
+	if sb.String() != want {
+		t.Fatalf("expected %q, got %q", want, sb.String())
+	}
+
+	if err := WriteKeysCSV(&sb, charSet, []Key{"a"}); err == nil {
+		t.Fatal("expected error for invalid key")
+	}
+}
+
+func TestWriteKeysSVG(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	var sb strings.Builder
+	noError(t, WriteKeysSVG(&sb, charSet, []Key{"25", "5"}, 100, 10))
+	want := `<svg xmlns="http://www.w3.org/2000/svg" width="100" height="10" viewBox="0 0 100 10">
+<line x1="25.00" y1="10" x2="25.00" y2="0.00" stroke="black"><title>25</title></line>
+<line x1="50.00" y1="10" x2="50.00" y2="5.00" stroke="black"><title>5</title></line>
+</svg>
+`
+	if sb.String() != want {
+		t.Fatalf("expected %q, got %q", want, sb.String())
+	}
+}
+
+func TestMigrateCSV(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	var sb strings.Builder
+	noError(t, MigrateCSV(&sb, strings.NewReader("title,pos\nb,2.5\na,-1\nc,10\n"), g, "pos"))
+	want := "title,pos,key\nb,2.5,5\na,-1,3\nc,10,7\n"
+	if sb.String() != want {
+		t.Fatalf("expected %q, got %q", want, sb.String())
+	}
+
+	if err := MigrateCSV(&sb, strings.NewReader("title,pos\na,x\n"), g, "pos"); err == nil {
+		t.Fatal("expected error for invalid position")
+	}
+	if err := MigrateCSV(&sb, strings.NewReader("title\na\n"), g, "pos"); err == nil {
+		t.Fatal("expected error for missing column")
+	}
+}
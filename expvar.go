@@ -0,0 +1,35 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"expvar"
+	"sync"
+)
+
+// WithExpvar returns a GeneratorOption that publishes statistics of the Generator to the expvar.Map:
+// "generated" is the number of generated keys, "errors" is the number of errors and "max_key_length" is
+// the length of the longest generated key. Keys returned with ErrKeySpaceDense are counted as both.
+// Create the map with expvar.NewMap to serve it at /debug/vars.
+func WithExpvar(m *expvar.Map) GeneratorOption {
+	generated, errs, maxLength := new(expvar.Int), new(expvar.Int), new(expvar.Int)
+	m.Set("generated", generated)
+	m.Set("errors", errs)
+	m.Set("max_key_length", maxLength)
+	var mu sync.Mutex
+	return WithObserver(func(key Key, err error) {
+		if err != nil {
+			errs.Add(1)
+		}
+		if key == "" {
+			return
+		}
+		generated.Add(1)
+		n := int64(len([]rune(key)))
+		mu.Lock()
+		defer mu.Unlock()
+		if n > maxLength.Value() {
+			maxLength.Set(n)
+		}
+	})
+}
@@ -0,0 +1,32 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"expvar"
+	"testing"
+)
+
+func TestWithExpvar(t *testing.T) {
+	m := new(expvar.Map).Init()
+	g := NewGenerator(WithExpvar(m))
+
+	key, err := g.Initial()
+	noError(t, err)
+	_, err = g.Between(key, key+"1")
+	noError(t, err)
+	_, err = g.Between("b", "a")
+	if err == nil {
+		t.Fatal("expected error")
+	}
+
+	for name, want := range map[string]string{
+		"generated":      "2",
+		"errors":         "1",
+		"max_key_length": "8",
+	} {
+		if got := m.Get(name).String(); got != want {
+			t.Errorf("%s: expected %s, got %s", name, want, got)
+		}
+	}
+}
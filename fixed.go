@@ -0,0 +1,37 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrGapExhausted is returned when no key of the fixed width set by WithFixedWidth fits between the keys.
+// Keys should be rebalanced, e.g. into a new bucket.
+var ErrGapExhausted = withCode(CodeGapExhausted, errors.New("gap exhausted"))
+
+// WithFixedWidth returns a GeneratorOption that makes every generated key n characters long.
+// The key is centered within the keys of the width between the keys, and Between returns an error wrapping
+// ErrGapExhausted if there is none. The initial key is used only if it is n characters long; otherwise
+// the initial key is the center of the keyspace.
+func WithFixedWidth(n int) GeneratorOption {
+	return func(g *Generator) {
+		g.fixedWidth = n
+	}
+}
+
+func (g *Generator) betweenFixedWidth(prevKey, nextKey Key) (Key, error) {
+	if prevKey == "" && nextKey == "" && len([]rune(g.initial)) == g.fixedWidth {
+		return Key(g.initial), nil
+	}
+	if nextKey != "" && prevKey >= nextKey {
+		return "", withCode(CodeOrderViolation, fmt.Errorf("prevKey (%q) must be strictly less than nextKey (%q)", prevKey, nextKey))
+	}
+	key, ok, err := centerWithLength(newKeyDigits(g.characterSet), prevKey, nextKey, g.fixedWidth)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", fmt.Errorf("%w: no key of %d characters between %q and %q", ErrGapExhausted, g.fixedWidth, prevKey, nextKey)
+	}
+	return key, nil
+}
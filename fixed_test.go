@@ -0,0 +1,41 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWithFixedWidth(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithFixedWidth(3))
+
+	for _, tt := range []struct {
+		prev Key
+		next Key
+		want Key
+	}{
+		{"", "", "500"},
+		{"500", "", "750"},
+		{"", "500", "250"},
+		{"500", "510", "505"},
+		{"500", "502", "501"},
+	} {
+		t.Run(fmt.Sprintf("%s_%s", tt.prev, tt.next), func(t *testing.T) {
+			key, err := g.Between(tt.prev, tt.next)
+			noError(t, err)
+			equalKey(t, key, tt.want)
+		})
+	}
+
+	_, err = g.Between("500", "501")
+	if !errors.Is(err, ErrGapExhausted) {
+		t.Fatalf("expected ErrGapExhausted, got %v", err)
+	}
+
+	key, err := NewGenerator(WithCharacterSet(charSet), WithInitial("123"), WithFixedWidth(3)).Initial()
+	noError(t, err)
+	equalKey(t, key, "123")
+}
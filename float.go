@@ -0,0 +1,62 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrPrecisionExhausted is returned by FloatRank when no float64 position fits between the positions.
+// Positions should be rebalanced or migrated to keys of a Generator.
+var ErrPrecisionExhausted = withCode(CodePrecisionExhausted, errors.New("precision exhausted"))
+
+// FloatRank generates float64 positions, which is enough for small lists that rarely insert at the same spot.
+// Positions sort in the same order as the keys converted from them, so that lists can be migrated to keys
+// of a Generator when the precision runs out.
+type FloatRank struct {
+	initial float64
+	step    float64
+}
+
+// NewFloatRank creates a new FloatRank whose first position is initial and Next and Prev move by step.
+func NewFloatRank(initial, step float64) *FloatRank {
+	return &FloatRank{initial, step}
+}
+
+// Initial returns the initial position.
+func (f *FloatRank) Initial() float64 {
+	return f.initial
+}
+
+// Next returns a position after the position.
+func (f *FloatRank) Next(p float64) (float64, error) {
+	return f.Between(p, math.Inf(1))
+}
+
+// Prev returns a position before the position.
+func (f *FloatRank) Prev(p float64) (float64, error) {
+	return f.Between(math.Inf(-1), p)
+}
+
+// Between returns the midpoint of the positions. An infinite position means the start or the end of the list.
+// It returns an error wrapping ErrPrecisionExhausted if there is no float64 strictly between the positions.
+func (f *FloatRank) Between(prev, next float64) (float64, error) {
+	if math.IsNaN(prev) || math.IsNaN(next) || prev >= next {
+		return 0, withCode(CodeOrderViolation, fmt.Errorf("prev (%v) must be strictly less than next (%v)", prev, next))
+	}
+	var p float64
+	switch {
+	case math.IsInf(prev, -1) && math.IsInf(next, 1):
+		return f.initial, nil
+	case math.IsInf(prev, -1):
+		p = next - f.step
+	case math.IsInf(next, 1):
+		p = prev + f.step
+	default:
+		p = prev + (next-prev)/2
+	}
+	if p <= prev || p >= next || math.IsInf(p, 0) {
+		return 0, fmt.Errorf("%w: %v - %v", ErrPrecisionExhausted, prev, next)
+	}
+	return p, nil
+}
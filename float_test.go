@@ -0,0 +1,53 @@
+package lexorank
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFloatRank(t *testing.T) {
+	f := NewFloatRank(0, 1)
+
+	p, err := f.Between(math.Inf(-1), math.Inf(1))
+	noError(t, err)
+	if p != f.Initial() {
+		t.Fatalf("expected initial position, got %v", p)
+	}
+
+	for _, tt := range []struct {
+		prev float64
+		next float64
+		want float64
+	}{
+		{0, math.Inf(1), 1},
+		{math.Inf(-1), 0, -1},
+		{0, 1, 0.5},
+	} {
+		got, err := f.Between(tt.prev, tt.next)
+		noError(t, err)
+		if got != tt.want {
+			t.Fatalf("%v-%v: expected %v, got %v", tt.prev, tt.next, tt.want, got)
+		}
+	}
+
+	next := 2.0
+	for n := 0; ; n++ {
+		p, err := f.Between(1, next)
+		if errors.Is(err, ErrPrecisionExhausted) {
+			if n != 52 {
+				t.Fatalf("expected precision to be exhausted after 52 insertions, got %d", n)
+			}
+			break
+		}
+		noError(t, err)
+		next = p
+	}
+
+	if _, err := f.Between(1, 1); err == nil {
+		t.Fatal("expected error for equal positions")
+	}
+	if _, err := f.Next(math.MaxFloat64); !errors.Is(err, ErrPrecisionExhausted) {
+		t.Fatalf("expected ErrPrecisionExhausted, got %v", err)
+	}
+}
@@ -0,0 +1,120 @@
+package lexorank
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+)
+
+// Forecast estimates how many more keys can be inserted into the sorted keys with the pattern before a generated
+// key becomes longer than maxLength.
+//   - InsertAtTail and InsertAtHead count the keys generated by Next after the last key and Prev before
+//     the first key respectively.
+//   - InsertInSameGap simulates splitting the narrowest gap between the keys repeatedly.
+//   - InsertAtRandom counts the keys of at most maxLength characters not used yet, which is the upper bound
+//     when insertions are spread evenly.
+func (g *Generator) Forecast(keys []Key, pattern InsertionPattern, maxLength int) (*big.Int, error) {
+	if maxLength <= 0 {
+		return nil, withCode(CodeInvalidArgument, fmt.Errorf("maxLength must be positive: %d", maxLength))
+	}
+	if !slices.IsSorted(keys) {
+		return nil, withCode(CodeInvalidArgument, fmt.Errorf("keys must be sorted"))
+	}
+	if g.keyPrefix != "" {
+		unprefixed, stripped, err := g.unprefixed(keys)
+		if err != nil {
+			return nil, err
+		}
+		return unprefixed.Forecast(stripped, pattern, maxLength)
+	}
+	if len(keys) == 0 {
+		initial, err := g.Initial()
+		if err != nil {
+			return nil, err
+		}
+		keys = []Key{initial}
+	}
+	d := newKeyDigits(g.characterSet)
+	switch pattern {
+	case InsertAtTail:
+		return forecastTail(d, keys[len(keys)-1], maxLength)
+	case InsertAtHead:
+		return forecastHead(d, keys[0], maxLength)
+	case InsertInSameGap:
+		return g.forecastSameGap(keys, maxLength)
+	case InsertAtRandom:
+		density, err := g.Density("", "", keys)
+		if err != nil {
+			return nil, err
+		}
+		lo, hi, err := keyRangeWithLength(d, "", "", maxLength)
+		if err != nil {
+			return nil, err
+		}
+		n := new(big.Int).Sub(hi, lo)
+		n.Add(n, big.NewInt(1)).Sub(n, big.NewInt(int64(density.Used)))
+		if n.Sign() < 0 {
+			n.SetInt64(0)
+		}
+		return n, nil
+	default:
+		return nil, withCode(CodeInvalidArgument, fmt.Errorf("unknown insertion pattern: %v", pattern))
+	}
+}
+
+// forecastTail counts the keys generated by Next, which increments the key as a number of its length until
+// all the characters are max, and then appends the character next to min.
+func forecastTail(d keyDigits, last Key, maxLength int) (*big.Int, error) {
+	length := len([]rune(last))
+	v, err := d.toInt(last, length)
+	if err != nil {
+		return nil, err
+	}
+	n := new(big.Int)
+	for ; length <= maxLength; length++ {
+		n.Add(n, new(big.Int).Sub(d.max(length), v))
+		if length < maxLength {
+			// The key of all max characters followed by the character next to min.
+			n.Add(n, big.NewInt(1))
+			v = new(big.Int).Mul(d.max(length), d.base)
+			v.Add(v, big.NewInt(1))
+		}
+	}
+	return n, nil
+}
+
+// forecastHead counts the keys generated by Prev, which decrements the key as a number of its length without
+// making it longer, until all the characters are min.
+func forecastHead(d keyDigits, first Key, maxLength int) (*big.Int, error) {
+	length := len([]rune(first))
+	if length > maxLength {
+		return new(big.Int), nil
+	}
+	return d.toInt(first, length)
+}
+
+func (g *Generator) forecastSameGap(keys []Key, maxLength int) (*big.Int, error) {
+	if len(keys) == 1 {
+		next, err := g.Next(keys[0])
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, next)
+	}
+	var fewest int64 = -1
+	for i := 1; i < len(keys); i++ {
+		prev, next := keys[i-1], keys[i]
+		var n int64
+		for ; ; n++ {
+			key, err := g.Between(prev, next)
+			if err != nil || len([]rune(key)) > maxLength {
+				break
+			}
+			next = key
+		}
+		if fewest < 0 || n < fewest {
+			fewest = n
+		}
+	}
+	return big.NewInt(fewest), nil
+}
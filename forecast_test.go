@@ -0,0 +1,50 @@
+package lexorank
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGenerator_Forecast(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"))
+
+	for _, tt := range []struct {
+		keys      []Key
+		pattern   InsertionPattern
+		maxLength int
+		want      int64
+	}{
+		{[]Key{"5"}, InsertAtTail, 1, 4},
+		{[]Key{"5"}, InsertAtTail, 2, 4 + 1 + 8},
+		{[]Key{"5"}, InsertAtHead, 2, 5},
+		{[]Key{"5", "6"}, InsertInSameGap, 2, 3},
+		{[]Key{"5"}, InsertAtRandom, 2, 98},
+	} {
+		t.Run(fmt.Sprintf("%v_%d", tt.pattern, tt.maxLength), func(t *testing.T) {
+			n, err := g.Forecast(tt.keys, tt.pattern, tt.maxLength)
+			noError(t, err)
+			if n.Int64() != tt.want {
+				t.Fatalf("expected %d, got %s", tt.want, n)
+			}
+		})
+	}
+
+	// The forecast for the tail matches the number of keys actually generated.
+	report, err := g.Stress(InsertAtTail, 100)
+	noError(t, err)
+	for i, length := range report.Lengths {
+		if length > 2 {
+			if i != 13 {
+				t.Fatalf("expected 13 keys of at most 2 characters, got %d", i)
+			}
+			break
+		}
+	}
+
+	if _, err := g.Forecast([]Key{"6", "5"}, InsertAtTail, 2); err == nil {
+		t.Fatal("expected error for unsorted keys")
+	}
+}
@@ -0,0 +1,40 @@
+package lexorank
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// WithFractionalPositions returns a GeneratorOption that generates keys from the exact midpoint of the fractions
+// represented by the keys, as KeyToRat, instead of splicing characters.
+// An empty prevKey is the fraction 0 and an empty nextKey is the fraction 1.
+// The midpoint is rendered with at most one more character than the longer key, truncated if the set has
+// an odd number of characters, so keys are balanced between both neighbors at the cost of big.Rat arithmetic
+// on each generation.
+// It is a shorthand for WithAlgorithm(FractionalAlgorithm).
+func WithFractionalPositions() GeneratorOption {
+	return WithAlgorithm(FractionalAlgorithm)
+}
+
+func (g *Generator) betweenFractional(prevKey, nextKey Key) (Key, error) {
+	d := newKeyDigits(g.characterSet)
+	prev, err := d.toRat(prevKey)
+	if err != nil {
+		return "", err
+	}
+	next := big.NewRat(1, 1)
+	if nextKey != "" {
+		if next, err = d.toRat(nextKey); err != nil {
+			return "", err
+		}
+	}
+	if prev.Cmp(next) >= 0 {
+		return "", withCode(CodeOrderViolation, fmt.Errorf("prevKey (%q) must represent a fraction strictly less than nextKey (%q)", prevKey, nextKey))
+	}
+	mid := new(big.Rat).Add(prev, next)
+	mid.Quo(mid, big.NewRat(2, 1))
+	// With a base greater than 2, the midpoint truncated to one more character than the longer key is still
+	// strictly between the keys.
+	length := max(len([]rune(prevKey)), len([]rune(nextKey))) + 1
+	return RatToKey(g.characterSet, mid, length)
+}
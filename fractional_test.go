@@ -0,0 +1,40 @@
+package lexorank
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestWithFractionalPositions(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithFractionalPositions())
+
+	for _, tt := range []struct {
+		prev Key
+		next Key
+		want Key
+	}{
+		{"", "", "5"},
+		{"5", "", "75"},
+		{"", "5", "25"},
+		{"5", "6", "55"},
+		{"1", "19", "145"},
+		{"99", "", "995"},
+	} {
+		t.Run(fmt.Sprintf("%s_%s", tt.prev, tt.next), func(t *testing.T) {
+			key, err := g.Between(tt.prev, tt.next)
+			noError(t, err)
+			equalKey(t, key, tt.want)
+		})
+	}
+
+	if _, err := g.Between("5", "50"); err == nil {
+		t.Fatal("expected error for keys of the same fraction")
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		testRecursive(t, NewGenerator(WithFractionalPositions()), "", "", 12)
+	})
+}
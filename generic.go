@@ -0,0 +1,46 @@
+package lexorank
+
+// GeneratorOf generates keys of an application-defined string type K, such as `type Rank string`,
+// so that keys do not have to be converted to and from Key.
+type GeneratorOf[K ~string] struct {
+	generator *Generator
+}
+
+// NewGeneratorOf creates a new GeneratorOf generating keys with the Generator. If g is nil, NewGenerator() is used.
+func NewGeneratorOf[K ~string](g *Generator) *GeneratorOf[K] {
+	if g == nil {
+		g = NewGenerator()
+	}
+	return &GeneratorOf[K]{g}
+}
+
+// Generator returns the underlying Generator.
+func (g *GeneratorOf[K]) Generator() *Generator {
+	return g.generator
+}
+
+// Between generates a key that comes between the prevKey and nextKey keys. See Generator.Between.
+func (g *GeneratorOf[K]) Between(prevKey, nextKey K) (K, error) {
+	key, err := g.generator.Between(Key(prevKey), Key(nextKey))
+	return K(key), err
+}
+
+// Next generates a key that comes after the given key.
+func (g *GeneratorOf[K]) Next(key K) (K, error) {
+	return g.Between(key, "")
+}
+
+// Prev generates a key that comes before the given key.
+func (g *GeneratorOf[K]) Prev(key K) (K, error) {
+	return g.Between("", key)
+}
+
+// Initial generates the initial key.
+func (g *GeneratorOf[K]) Initial() (K, error) {
+	return g.Between("", "")
+}
+
+// InsertAt generates a key to insert into the sorted keys at the index. See Generator.InsertAt.
+func (g *GeneratorOf[K]) InsertAt(keys []K, index int) (K, error) {
+	return insertAt(keys, index, g.Between)
+}
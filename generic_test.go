@@ -0,0 +1,42 @@
+package lexorank
+
+import (
+	"errors"
+	"testing"
+)
+
+type genericTestRank string
+
+func TestGeneratorOf(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGeneratorOf[genericTestRank](NewGenerator(WithCharacterSet(charSet), WithInitial("5")))
+
+	rank, err := g.Initial()
+	noError(t, err)
+	if rank != "5" {
+		t.Fatalf("expected 5, got %s", rank)
+	}
+
+	next, err := g.Next(rank)
+	noError(t, err)
+	if next != "6" {
+		t.Fatalf("expected 6, got %s", next)
+	}
+
+	between, err := g.InsertAt([]genericTestRank{rank, next}, 1)
+	noError(t, err)
+	if between != "54" {
+		t.Fatalf("expected 54, got %s", between)
+	}
+
+	_, err = g.Between(next, rank)
+	var betweenErr *BetweenError
+	if !errors.As(err, &betweenErr) {
+		t.Fatalf("expected *BetweenError, got %v", err)
+	}
+	if _, err := g.InsertAt(nil, 1); ErrorCode(err) != CodeInvalidArgument {
+		t.Fatalf("expected invalid argument, got %v", err)
+	}
+}
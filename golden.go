@@ -0,0 +1,60 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"bytes"
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// GoldenVector is a single expected result of Generator.Between.
+// Golden vectors are shared with ports in other languages to verify that they produce byte-identical keys.
+type GoldenVector struct {
+	CharacterSet string `json:"character_set"`
+	// Initial is the initial key of the generator. Empty means the default initial key.
+	Initial  string `json:"initial,omitempty"`
+	Prev     Key    `json:"prev"`
+	Next     Key    `json:"next"`
+	Expected Key    `json:"expected"`
+}
+
+// Verify checks that the Generator configured by the vector produces the expected key.
+func (v GoldenVector) Verify() error {
+	set, err := NewASCIICharacterSet(v.CharacterSet)
+	if err != nil {
+		return err
+	}
+	opts := []GeneratorOption{WithCharacterSet(set)}
+	if v.Initial != "" {
+		opts = append(opts, WithInitial(v.Initial))
+	}
+	got, err := NewGenerator(opts...).Between(v.Prev, v.Next)
+	if err != nil {
+		return err
+	}
+	if got != v.Expected {
+		return fmt.Errorf("golden vector mismatch: %q - %q: expected %q, got %q", v.Prev, v.Next, v.Expected, got)
+	}
+	return nil
+}
+
+//go:embed testdata/golden.json
+var goldenJSON []byte
+
+// GoldenVectors returns the golden vectors bundled with this package.
+// The same vectors are available as JSON in testdata/golden.json.
+func GoldenVectors() ([]GoldenVector, error) {
+	return ReadGoldenVectors(bytes.NewReader(goldenJSON))
+}
+
+// ReadGoldenVectors reads golden vectors in JSON format from r.
+func ReadGoldenVectors(r io.Reader) ([]GoldenVector, error) {
+	var vectors []GoldenVector
+	if err := json.NewDecoder(r).Decode(&vectors); err != nil {
+		return nil, fmt.Errorf("invalid golden vectors: %w", err)
+	}
+	return vectors, nil
+}
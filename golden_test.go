@@ -0,0 +1,52 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestGoldenVectors(t *testing.T) {
+	f, err := os.Open("testdata/golden.json")
+	noError(t, err)
+	defer f.Close()
+
+	vectors, err := ReadGoldenVectors(f)
+	noError(t, err)
+	if len(vectors) == 0 {
+		t.Fatal("expected golden vectors, got none")
+	}
+	bundled, err := GoldenVectors()
+	noError(t, err)
+	if len(vectors) != len(bundled) {
+		t.Fatalf("expected %d bundled vectors, got %d", len(vectors), len(bundled))
+	}
+
+	for _, v := range vectors {
+		noError(t, v.Verify())
+	}
+}
+
+func TestGoldenVector_Verify_Error(t *testing.T) {
+	for _, v := range []GoldenVector{
+		{"", "", "", "", "5"},
+		{"0123456789", "", "4", "3", "5"},
+		{"0123456789", "", "1\xff", "", "5"},
+	} {
+		err := v.Verify()
+		if err == nil {
+			t.Fatalf("%+v: expected error, but got nil", v)
+		}
+		var invariantErr *InvariantError
+		if errors.As(err, &invariantErr) {
+			t.Fatalf("%+v: expected an error other than a panic, got %v", v, err)
+		}
+	}
+
+	if _, err := ReadGoldenVectors(strings.NewReader(`{"broken"`)); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+}
@@ -0,0 +1,48 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+// MarshalGQL implements graphql.Marshaler of github.com/99designs/gqlgen, writing the key as a string,
+// so that Key can be bound to a custom scalar.
+func (k Key) MarshalGQL(w io.Writer) {
+	writeGQLString(w, string(k))
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler of github.com/99designs/gqlgen.
+// It returns an error if v is not a string.
+func (k *Key) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return withCode(CodeInvalidArgument, fmt.Errorf("cannot unmarshal %T into Key", v))
+	}
+	*k = Key(s)
+	return nil
+}
+
+// MarshalGQL implements graphql.Marshaler of github.com/99designs/gqlgen, writing the key as a string,
+// so that BucketKey can be bound to a custom scalar.
+func (k BucketKey) MarshalGQL(w io.Writer) {
+	writeGQLString(w, string(k))
+}
+
+// UnmarshalGQL implements graphql.Unmarshaler of github.com/99designs/gqlgen.
+// It returns an error if v is not a string or is not in the format "bucket|key".
+func (k *BucketKey) UnmarshalGQL(v any) error {
+	s, ok := v.(string)
+	if !ok {
+		return withCode(CodeInvalidArgument, fmt.Errorf("cannot unmarshal %T into BucketKey", v))
+	}
+	return k.set(s)
+}
+
+func writeGQLString(w io.Writer, s string) {
+	// Marshaling a string never fails.
+	b, _ := json.Marshal(s)
+	w.Write(b)
+}
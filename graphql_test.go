@@ -0,0 +1,43 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestKey_GQL(t *testing.T) {
+	var sb strings.Builder
+	Key(`a"b`).MarshalGQL(&sb)
+	if want := `"a\"b"`; sb.String() != want {
+		t.Fatalf("expected %s, got %s", want, sb.String())
+	}
+
+	var k Key
+	noError(t, k.UnmarshalGQL("abc"))
+	equalKey(t, k, "abc")
+	if err := k.UnmarshalGQL(1); err == nil {
+		t.Fatal("expected error for non-string value")
+	}
+}
+
+func TestBucketKey_GQL(t *testing.T) {
+	var sb strings.Builder
+	BucketKey("0|abc").MarshalGQL(&sb)
+	if want := `"0|abc"`; sb.String() != want {
+		t.Fatalf("expected %s, got %s", want, sb.String())
+	}
+
+	var k BucketKey
+	noError(t, k.UnmarshalGQL("0|abc"))
+	equalBucketKey(t, k, "0|abc")
+	noError(t, k.UnmarshalGQL("0:abc"))
+	equalBucketKey(t, k, "0:abc")
+	for _, v := range []any{"abc", 1, nil} {
+		if err := k.UnmarshalGQL(v); err == nil {
+			t.Fatalf("%v: expected error, but got nil", v)
+		}
+	}
+	equalBucketKey(t, k, "0:abc")
+}
@@ -0,0 +1,22 @@
+package lexorank
+
+import "sync/atomic"
+
+// WithAlternatingGrowth returns a GeneratorOption that alternates the character appended when the gap between
+// two keys cannot be split without lengthening the key. The character is taken from the lower quarter and
+// the upper quarter of the set in turn, instead of the midpoint, so that repeated insertions at the same spot
+// leave room on both sides and the lengths of keys derived from either neighbor stay balanced.
+// The alternation is shared by clones of the Generator and is safe for concurrent use.
+func WithAlternatingGrowth() GeneratorOption {
+	return func(g *Generator) {
+		g.growth = new(atomic.Uint64)
+	}
+}
+
+func (g *Generator) growthChar() rune {
+	strategy := BiasedLeftMidpoint
+	if g.growth.Add(1)%2 == 0 {
+		strategy = BiasedRightMidpoint
+	}
+	return strategy.Midpoint(g.characterSet, g.characterSet.Min(), g.characterSet.Max(), g.rand)
+}
@@ -0,0 +1,22 @@
+package lexorank
+
+import "testing"
+
+func TestWithAlternatingGrowth(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithAlternatingGrowth())
+
+	next := Key("6")
+	for _, want := range []Key{"52", "51", "506", "503", "501", "5002"} {
+		key, err := g.Between("5", next)
+		noError(t, err)
+		equalKey(t, key, want)
+		next = key
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		testRecursive(t, NewGenerator(WithAlternatingGrowth()), "", "", 12)
+	})
+}
@@ -0,0 +1,86 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// NewHandler returns an http.Handler that exposes the key generation of g as a JSON API.
+//
+// The handler serves the following endpoints, all of which respond with {"key": "..."}:
+//   - POST /between with {"prev": "...", "next": "..."}
+//   - POST /next with {"key": "..."}
+//   - POST /prev with {"key": "..."}
+//   - POST /initial
+//
+// Errors are responded with status 400 and {"error": "...", "code": "..."}, where code is ErrorCode of the error.
+// Use http.StripPrefix to mount the handler under a path.
+func NewHandler(g *Generator) http.Handler {
+	return newHandler(func(prev, next string) (string, error) {
+		key, err := g.Between(Key(prev), Key(next))
+		return string(key), err
+	})
+}
+
+// NewBucketHandler returns an http.Handler that exposes the key generation of b as a JSON API.
+// See NewHandler for the endpoints.
+func NewBucketHandler(b *Bucket) http.Handler {
+	return newHandler(func(prev, next string) (string, error) {
+		key, err := b.Between(BucketKey(prev), BucketKey(next))
+		return string(key), err
+	})
+}
+
+type handlerRequest struct {
+	Prev string `json:"prev"`
+	Next string `json:"next"`
+	Key  string `json:"key"`
+}
+
+type handlerResponse struct {
+	Key   string `json:"key,omitempty"`
+	Error string `json:"error,omitempty"`
+	Code  string `json:"code,omitempty"`
+}
+
+func newHandler(between func(prev, next string) (string, error)) http.Handler {
+	mux := http.NewServeMux()
+	handle := func(pattern string, f func(req handlerRequest) (string, error)) {
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			var req handlerRequest
+			if r.ContentLength != 0 {
+				if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+					writeHandlerResponse(w, http.StatusBadRequest, handlerResponse{Error: "invalid request body: " + err.Error(), Code: CodeInvalidArgument})
+					return
+				}
+			}
+			key, err := f(req)
+			if err != nil {
+				writeHandlerResponse(w, http.StatusBadRequest, handlerResponse{Error: err.Error(), Code: ErrorCode(err)})
+				return
+			}
+			writeHandlerResponse(w, http.StatusOK, handlerResponse{Key: key})
+		})
+	}
+	handle("POST /between", func(req handlerRequest) (string, error) {
+		return between(req.Prev, req.Next)
+	})
+	handle("POST /next", func(req handlerRequest) (string, error) {
+		return between(req.Key, "")
+	})
+	handle("POST /prev", func(req handlerRequest) (string, error) {
+		return between("", req.Key)
+	})
+	handle("POST /initial", func(handlerRequest) (string, error) {
+		return between("", "")
+	})
+	return mux
+}
+
+func writeHandlerResponse(w http.ResponseWriter, status int, res handlerResponse) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,48 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("555"))
+	h := NewHandler(g)
+	bh := NewBucketHandler(NewBucket(WithGenerator(g)))
+
+	for _, tt := range []struct {
+		handler http.Handler
+		path    string
+		body    string
+		status  int
+		want    string
+	}{
+		{h, "/initial", "", http.StatusOK, `{"key":"555"}`},
+		{h, "/next", `{"key":"555"}`, http.StatusOK, `{"key":"556"}`},
+		{h, "/prev", `{"key":"555"}`, http.StatusOK, `{"key":"554"}`},
+		{h, "/between", `{"prev":"699","next":"700"}`, http.StatusOK, `{"key":"6994"}`},
+		{h, "/between", `{"prev":"700","next":"699"}`, http.StatusBadRequest, `{"error":"prevKey (\"700\") must be strictly less than nextKey (\"699\")","code":"ORDER_VIOLATION"}`},
+		{h, "/between", `{`, http.StatusBadRequest, `{"error":"invalid request body: unexpected EOF","code":"INVALID_ARGUMENT"}`},
+		{bh, "/initial", "", http.StatusOK, `{"key":"0|555"}`},
+		{bh, "/next", `{"key":"1|555"}`, http.StatusOK, `{"key":"1|556"}`},
+		{bh, "/between", `{"prev":"0|555","next":"1|555"}`, http.StatusBadRequest, `{"error":"bucket mismatch: \"0\" != \"1\"","code":"BUCKET_MISMATCH"}`},
+	} {
+		t.Run(tt.path+"_"+tt.body, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			tt.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, tt.path, strings.NewReader(tt.body)))
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if got := strings.TrimSpace(rec.Body.String()); got != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package lexorank
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ImportTrelloPositions returns keys of the Generator preserving the order of Trello "pos" values of cards or
+// lists, which are floating-point numbers. keys[i] is the key for positions[i]. See PositionsToKeys.
+func ImportTrelloPositions(g *Generator, positions []float64) ([]Key, error) {
+	for i, pos := range positions {
+		if math.IsNaN(pos) || math.IsInf(pos, 0) {
+			return nil, withCode(CodeInvalidArgument, fmt.Errorf("invalid position at %d: %v", i, pos))
+		}
+	}
+	return PositionsToKeys(g, positions)
+}
+
+// ImportJiraRanks returns keys of the Generator preserving the order of Jira ranks such as "0|hzzzzz:", which
+// are exported from the Rank field of issues and compared as strings. keys[i] is the key for ranks[i].
+// See PositionsToKeys.
+func ImportJiraRanks(g *Generator, ranks []string) ([]Key, error) {
+	for i, rank := range ranks {
+		bucket, value, ok := strings.Cut(rank, "|")
+		if !ok || bucket == "" || value == "" {
+			return nil, withCode(CodeInvalidKey, fmt.Errorf("invalid Jira rank at %d: %q", i, rank))
+		}
+	}
+	return PositionsToKeys(g, ranks)
+}
@@ -0,0 +1,41 @@
+package lexorank
+
+import (
+	"math"
+	"slices"
+	"testing"
+)
+
+func TestImportTrelloPositions(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	keys, err := ImportTrelloPositions(g, []float64{65535, 16384, 131071})
+	noError(t, err)
+	if !slices.Equal(keys, []Key{"5", "3", "7"}) {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+
+	if _, err := ImportTrelloPositions(g, []float64{1, math.NaN()}); err == nil {
+		t.Fatal("expected error for NaN")
+	}
+}
+
+func TestImportJiraRanks(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	keys, err := ImportJiraRanks(g, []string{"0|i0000f:", "0|hzzzzz:", "0|i00007:"})
+	noError(t, err)
+	if !slices.Equal(keys, []Key{"7", "3", "5"}) {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+
+	if _, err := ImportJiraRanks(g, []string{"hzzzzz"}); err == nil {
+		t.Fatal("expected error for a rank without bucket")
+	}
+}
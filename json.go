@@ -0,0 +1,21 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import "encoding/json"
+
+// MarshalJSON implements json.Marshaler. The map is encoded as its Snapshot.
+func (m *RankedMap[ID]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.sorted)
+}
+
+// UnmarshalJSON implements json.Unmarshaler. The map is restored from the encoded Snapshot,
+// keeping the Generator of the map.
+func (m *RankedMap[ID]) UnmarshalJSON(data []byte) error {
+	var snapshot []Ranked[ID]
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return err
+	}
+	m.Restore(snapshot)
+	return nil
+}
@@ -0,0 +1,32 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+func TestRankedMap_JSON(t *testing.T) {
+	g := NewGenerator()
+	m := NewRankedMap[int](g)
+	m.Set(2, "b")
+	m.Set(1, "a")
+
+	data, err := json.Marshal(m)
+	noError(t, err)
+	if want := `[{"id":1,"key":"a"},{"id":2,"key":"b"}]`; string(data) != want {
+		t.Fatalf("expected %s, got %s", want, data)
+	}
+
+	restored := NewRankedMap[int](g)
+	restored.Set(3, "c")
+	noError(t, json.Unmarshal(data, restored))
+	if got, want := restored.Snapshot(), m.Snapshot(); !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if _, ok := restored.Key(3); ok {
+		t.Fatal("expected 3 to be removed by restore")
+	}
+}
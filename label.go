@@ -0,0 +1,41 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// NextLabel returns the bucket label following label, treating the label as a fixed-width counter over the
+// character set, e.g. "00" → "01", "0z" → "10" and "zz" → "00" for digits and lowercase letters.
+// A label of n characters is reused only after all other labels of n characters have been used.
+func NextLabel(set CharacterSet, label string) (string, error) {
+	return stepLabel(set, label, set.Next, set.Min())
+}
+
+// PrevLabel returns the bucket label preceding label. It is the inverse of NextLabel.
+func PrevLabel(set CharacterSet, label string) (string, error) {
+	return stepLabel(set, label, set.Prev, set.Max())
+}
+
+func stepLabel(set CharacterSet, label string, step func(rune) (rune, bool), wrap rune) (string, error) {
+	if label == "" {
+		return "", withCode(CodeInvalidArgument, errors.New("label must not be empty"))
+	}
+	runes := []rune(label)
+	chars := characterSetRunes(set)
+	for _, r := range runes {
+		if !slices.Contains(chars, r) {
+			return "", withCode(CodeInvalidKey, fmt.Errorf("invalid label %q: '%c' is not in the character set", label, r))
+		}
+	}
+	for i := len(runes) - 1; i >= 0; i-- {
+		r, ok := step(runes[i])
+		if ok {
+			runes[i] = r
+			break
+		}
+		runes[i] = wrap
+	}
+	return string(runes), nil
+}
@@ -0,0 +1,38 @@
+package lexorank
+
+import "testing"
+
+func TestNextLabel(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789abcdefghijklmnopqrstuvwxyz")
+	noError(t, err)
+
+	for _, tt := range []struct {
+		label string
+		next  string
+	}{
+		{"0", "1"},
+		{"00", "01"},
+		{"09", "0a"},
+		{"0z", "10"},
+		{"zz", "00"},
+	} {
+		next, err := NextLabel(charSet, tt.label)
+		noError(t, err)
+		if next != tt.next {
+			t.Fatalf("NextLabel(%q): expected %q, got %q", tt.label, tt.next, next)
+		}
+
+		prev, err := PrevLabel(charSet, next)
+		noError(t, err)
+		if prev != tt.label {
+			t.Fatalf("PrevLabel(%q): expected %q, got %q", next, tt.label, prev)
+		}
+	}
+
+	if _, err := NextLabel(charSet, ""); err == nil {
+		t.Fatal("expected error for empty label")
+	}
+	if _, err := NextLabel(charSet, "0A"); err == nil {
+		t.Fatal("expected error for invalid character")
+	}
+}
@@ -0,0 +1,216 @@
+package lexorank
+
+import (
+	"fmt"
+	"math/big"
+	"runtime"
+	"strings"
+	"sync"
+)
+
+// BetweenWithLength generates a key of the given length that comes between the prevKey and nextKey keys.
+// The key is centered within the keys of that length in the gap, so that later insertions have room on both sides.
+// If no key of the length fits between prevKey and nextKey, it falls back to the key returned by Between.
+func (g *Generator) BetweenWithLength(prevKey, nextKey Key, length int) (Key, error) {
+	if length <= 0 {
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("length must be positive: %d", length))
+	}
+	if nextKey != "" && prevKey >= nextKey {
+		return "", withCode(CodeOrderViolation, fmt.Errorf("prevKey (%q) must be strictly less than nextKey (%q)", prevKey, nextKey))
+	}
+
+	prev, err := g.rawKey(prevKey)
+	if err != nil {
+		return "", err
+	}
+	next, err := g.rawKey(nextKey)
+	if err != nil {
+		return "", err
+	}
+	prev, next = g.clampPinned(prev, next)
+	key, ok, err := centerWithLength(newKeyDigits(g.characterSet), prev, next, length)
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return g.Between(prevKey, nextKey)
+	}
+	return g.finishKey(key)
+}
+
+// NBetween generates n evenly spaced keys of the shortest possible length in ascending order between the prevKey
+// and nextKey keys, e.g. to insert a block of items at once.
+// It returns an error wrapping ErrGapExhausted if the keys do not fit between prevKey and nextKey.
+func (g *Generator) NBetween(prevKey, nextKey Key, n int) ([]Key, error) {
+	if n <= 0 {
+		return nil, withCode(CodeInvalidArgument, fmt.Errorf("n must be positive: %d", n))
+	}
+	prev, err := g.rawKey(prevKey)
+	if err != nil {
+		return nil, err
+	}
+	next, err := g.rawKey(nextKey)
+	if err != nil {
+		return nil, err
+	}
+	prev, next = g.clampPinned(prev, next)
+	keys, err := spreadWithLength(newKeyDigits(g.characterSet), prev, next, n)
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range keys {
+		if keys[i], err = g.finishKey(key); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// Compact returns the shortest key that comes between the prevKey and nextKey keys,
+// to replace key whose neighbors are prevKey and nextKey.
+// If there is no key shorter than key, key itself is returned.
+func (g *Generator) Compact(key, prevKey, nextKey Key) (Key, error) {
+	if key <= prevKey || (nextKey != "" && key >= nextKey) {
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("key (%q) must be between prevKey (%q) and nextKey (%q)", key, prevKey, nextKey))
+	}
+
+	raw, err := g.rawKey(key)
+	if err != nil {
+		return "", err
+	}
+	if prevKey, err = g.rawKey(prevKey); err != nil {
+		return "", err
+	}
+	if nextKey, err = g.rawKey(nextKey); err != nil {
+		return "", err
+	}
+	d := newKeyDigits(g.characterSet)
+	for length := 1; length < len([]rune(raw)); length++ {
+		shorter, ok, err := centerWithLength(d, prevKey, nextKey, length)
+		if err != nil {
+			return "", err
+		}
+		if ok {
+			return g.finishKey(shorter)
+		}
+	}
+	return key, nil
+}
+
+// centerWithLength returns the key at the center of the keys of the length between prevKey and nextKey.
+// It returns false if there is no such key.
+func centerWithLength(d keyDigits, prevKey, nextKey Key, length int) (Key, bool, error) {
+	lo, hi, err := keyRangeWithLength(d, prevKey, nextKey, length)
+	if err != nil {
+		return "", false, err
+	}
+	if lo.Cmp(hi) > 0 {
+		return "", false, nil
+	}
+	mid := new(big.Int).Add(lo, hi)
+	mid.Rsh(mid, 1)
+	return d.fromInt(mid, length), true, nil
+}
+
+// keyRangeWithLength returns the smallest and the largest keys of the length between prevKey and nextKey
+// as numbers. prevKey followed by min characters is excluded, because no key fits between it and prevKey.
+// If there is no such key, lo is greater than hi.
+func keyRangeWithLength(d keyDigits, prevKey, nextKey Key, length int) (lo, hi *big.Int, err error) {
+	one := big.NewInt(1)
+
+	lo, err = d.toInt(prevKey, length)
+	if err != nil {
+		return nil, nil, err
+	}
+	lo.Add(lo, one)
+
+	hi = d.max(length)
+	if nextKey != "" {
+		hi, err = d.toInt(nextKey, length)
+		if err != nil {
+			return nil, nil, err
+		}
+		if len([]rune(nextKey)) <= length {
+			hi.Sub(hi, one)
+		}
+	}
+	return lo, hi, nil
+}
+
+// spreadWithLength returns n keys of the shortest possible length evenly spaced between prevKey and nextKey.
+func spreadWithLength(d keyDigits, prevKey, nextKey Key, n int) ([]Key, error) {
+	keyAt, err := spreadFunc(d, prevKey, nextKey, n)
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]Key, n)
+	fillKeys(keys, keyAt)
+	return keys, nil
+}
+
+// parallelThreshold is the number of keys per goroutine above which fillKeys generates keys concurrently.
+const parallelThreshold = 1 << 14
+
+// fillKeys sets keys[i] to keyAt(i). Many keys are generated by partitioning them across goroutines.
+func fillKeys(keys []Key, keyAt func(i int) Key) {
+	workers := min(runtime.GOMAXPROCS(0), len(keys)/parallelThreshold)
+	if workers <= 1 {
+		for i := range keys {
+			keys[i] = keyAt(i)
+		}
+		return
+	}
+	var wg sync.WaitGroup
+	size := (len(keys) + workers - 1) / workers
+	for start := 0; start < len(keys); start += size {
+		wg.Add(1)
+		go func(part []Key, start int) {
+			defer wg.Done()
+			for i := range part {
+				part[i] = keyAt(start + i)
+			}
+		}(keys[start:min(start+size, len(keys))], start)
+	}
+	wg.Wait()
+}
+
+// spreadFunc returns a function computing the i-th of the keys returned by spreadWithLength, so that the keys
+// can be generated one at a time.
+// It returns an error if prevKey is not less than nextKey, or an error wrapping ErrGapExhausted if no key fits
+// between them.
+func spreadFunc(d keyDigits, prevKey, nextKey Key, n int) (func(i int) Key, error) {
+	if nextKey != "" && prevKey >= nextKey {
+		return nil, withCode(CodeOrderViolation, fmt.Errorf("prevKey (%q) must be strictly less than nextKey (%q)", prevKey, nextKey))
+	}
+	if !hasGap(d, prevKey, nextKey) {
+		return nil, fmt.Errorf("%w: no key between %q and %q", ErrGapExhausted, prevKey, nextKey)
+	}
+	need := big.NewInt(int64(n))
+	for length := 1; ; length++ {
+		lo, hi, err := keyRangeWithLength(d, prevKey, nextKey, length)
+		if err != nil {
+			return nil, err
+		}
+		capacity := new(big.Int).Sub(hi, lo)
+		capacity.Add(capacity, big.NewInt(1))
+		if capacity.Cmp(need) < 0 {
+			continue
+		}
+		// Place the i-th key at lo + (i+1) * capacity / (n+1), so that the gaps at both ends are as wide as the others.
+		return func(i int) Key {
+			v := new(big.Int).Mul(capacity, big.NewInt(int64(i+1)))
+			v.Quo(v, big.NewInt(int64(n+1)))
+			return d.fromInt(v.Add(v, lo), length)
+		}, nil
+	}
+}
+
+// hasGap reports whether a key fits between prevKey and nextKey, which is false if nextKey is prevKey followed
+// only by min characters.
+func hasGap(d keyDigits, prevKey, nextKey Key) bool {
+	if nextKey == "" {
+		return true
+	}
+	rest, ok := strings.CutPrefix(string(nextKey), string(prevKey))
+	return !ok || strings.Trim(rest, string(d.runes[0])) != ""
+}
@@ -0,0 +1,134 @@
+package lexorank
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestGenerator_BetweenWithLength(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	for _, tt := range []struct {
+		prev   Key
+		next   Key
+		length int
+		want   Key
+	}{
+		{"", "", 3, "500"},
+		{"", "", 1, "5"},
+		{"5", "", 3, "750"},
+		{"", "5", 3, "250"},
+		{"4", "6", 3, "500"},
+		{"4", "41", 4, "4050"},
+		{"499", "5", 3, "4994"},
+		{"0001", "0002", 2, "00014"},
+		{"5", "6", 1, "54"},
+		{"5", "51", 2, "504"},
+	} {
+		t.Run(fmt.Sprintf("%s_%s_%d", tt.prev, tt.next, tt.length), func(t *testing.T) {
+			key, err := g.BetweenWithLength(tt.prev, tt.next, tt.length)
+			noError(t, err)
+			equalKey(t, key, tt.want)
+			validateKey(t, key, tt.prev, tt.next)
+
+			// The key must leave room for keys inserted next to it.
+			inserted, err := g.Between(tt.prev, key)
+			noError(t, err)
+			validateKey(t, inserted, tt.prev, key)
+		})
+	}
+
+	t.Run("error on invalid character", func(t *testing.T) {
+		if _, err := g.BetweenWithLength("a", "", 3); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
+
+func TestGenerator_NBetween(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	for _, tt := range []struct {
+		prev Key
+		next Key
+		n    int
+		want []Key
+	}{
+		{"", "", 3, []Key{"3", "5", "7"}},
+		{"4", "6", 1, []Key{"5"}},
+		{"4", "5", 3, []Key{"43", "45", "47"}},
+	} {
+		t.Run(fmt.Sprintf("%s_%s_%d", tt.prev, tt.next, tt.n), func(t *testing.T) {
+			keys, err := g.NBetween(tt.prev, tt.next, tt.n)
+			noError(t, err)
+			if !slices.Equal(keys, tt.want) {
+				t.Fatalf("expected %v, got %v", tt.want, keys)
+			}
+		})
+	}
+
+	t.Run("error", func(t *testing.T) {
+		for _, tt := range []struct {
+			prev Key
+			next Key
+			n    int
+			code string
+		}{
+			{"", "", 0, CodeInvalidArgument},
+			{"5", "4", 1, CodeOrderViolation},
+			{"5", "50", 1, CodeGapExhausted},
+		} {
+			if _, err := g.NBetween(tt.prev, tt.next, tt.n); ErrorCode(err) != tt.code {
+				t.Fatalf("%s_%s_%d: expected %s, got %v", tt.prev, tt.next, tt.n, tt.code, err)
+			}
+		}
+	})
+}
+
+func TestGenerator_Compact(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	for _, tt := range []struct {
+		key  Key
+		prev Key
+		next Key
+		want Key
+	}{
+		{"55555", "", "", "5"},
+		{"12345", "1", "2", "15"},
+		{"12345", "12", "2", "16"},
+		{"12345", "1234", "1235", "12345"},
+		{"1239", "123", "124", "1239"},
+		{"1239", "1238", "", "5"},
+		{"5", "", "", "5"},
+		{"505", "5", "51", "505"},
+	} {
+		t.Run(fmt.Sprintf("%s_%s_%s", tt.key, tt.prev, tt.next), func(t *testing.T) {
+			key, err := g.Compact(tt.key, tt.prev, tt.next)
+			noError(t, err)
+			equalKey(t, key, tt.want)
+			validateKey(t, key, tt.prev, tt.next)
+
+			// The key must leave room for keys inserted next to it.
+			inserted, err := g.Between(tt.prev, key)
+			noError(t, err)
+			validateKey(t, inserted, tt.prev, key)
+		})
+	}
+
+	t.Run("error on key out of range", func(t *testing.T) {
+		if _, err := g.Compact("3", "4", "5"); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
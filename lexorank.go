@@ -1,11 +1,15 @@
 package lexorank
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
+	"math/rand/v2"
 	"slices"
 	"strings"
+	"sync/atomic"
 	"unicode"
+	"unicode/utf8"
 )
 
 // CharacterSet defines a set of characters that can be used for key generation.
@@ -28,27 +32,39 @@ type CharacterSet interface {
 
 type characterSet struct {
 	runes       []rune
-	runeToIndex [128]int
+	runeToIndex [256]int
 }
 
 // NewASCIICharacterSet creates a new CharacterSet from a string of ASCII characters.
 func NewASCIICharacterSet(set string) (CharacterSet, error) {
-	runes := []rune(set)
-	slices.Sort(runes)
-	var runeToIndex [128]int
-	for i, r := range runes {
+	if set == "" {
+		return nil, withCode(CodeInvalidCharacterSet, errors.New("invalid character set: no characters"))
+	}
+	var seen [256]bool
+	for _, r := range set {
 		if !isASCII(r) {
-			return nil, fmt.Errorf("invalid character set: '%c' is not an ASCII character", r)
+			return nil, withCode(CodeInvalidCharacterSet, fmt.Errorf("invalid character set: '%c' is not an ASCII character", r))
 		}
-		if runeToIndex[r] != 0 {
-			return nil, fmt.Errorf("invalid character set: '%c' is duplicated", r)
+		if seen[r] {
+			return nil, withCode(CodeInvalidCharacterSet, fmt.Errorf("invalid character set: '%c' is duplicated", r))
 		}
+		seen[r] = true
+	}
+	return newCharacterSet(set), nil
+}
+
+// newCharacterSet creates a characterSet from distinct ASCII characters.
+func newCharacterSet(set string) *characterSet {
+	runes := []rune(set)
+	slices.Sort(runes)
+	var runeToIndex [256]int
+	for i, r := range runes {
 		runeToIndex[r] = i
 	}
 	return &characterSet{
 		runes,
 		runeToIndex,
-	}, nil
+	}
 }
 
 func (c *characterSet) Min() rune {
@@ -60,7 +76,7 @@ func (c *characterSet) Max() rune {
 }
 
 func (c *characterSet) Next(r rune) (rune, bool) {
-	index := c.runeToIndex[r]
+	index := c.index(r)
 	if index == len(c.runes)-1 {
 		return 0, false
 	}
@@ -69,7 +85,7 @@ func (c *characterSet) Next(r rune) (rune, bool) {
 }
 
 func (c *characterSet) Prev(r rune) (rune, bool) {
-	index := c.runeToIndex[r]
+	index := c.index(r)
 	if index == 0 {
 		return 0, false
 	}
@@ -78,8 +94,8 @@ func (c *characterSet) Prev(r rune) (rune, bool) {
 }
 
 func (c *characterSet) Mid(a, b rune) rune {
-	indexA := c.runeToIndex[a]
-	indexB := c.runeToIndex[b]
+	indexA := c.index(a)
+	indexB := c.index(b)
 	if indexB < indexA {
 		indexB += len(c.runes)
 	}
@@ -88,6 +104,15 @@ func (c *characterSet) Mid(a, b rune) rune {
 	return c.runes[index]
 }
 
+// index returns the index of r in the character set. Like any other character out of the set, a rune out of
+// the ASCII range, such as utf8.RuneError of an invalid key, is treated as the min character.
+func (c *characterSet) index(r rune) int {
+	if r < 0 || int(r) >= len(c.runeToIndex) {
+		return 0
+	}
+	return c.runeToIndex[r]
+}
+
 func isASCII(r rune) bool {
 	return r >= 0 && r <= unicode.MaxASCII
 }
@@ -102,7 +127,7 @@ func ValidateCharacterSet(set CharacterSet) error {
 			break
 		}
 		if r >= next {
-			return fmt.Errorf("invalid character set: '%c' >= '%c'", r, next)
+			return withCode(CodeInvalidCharacterSet, fmt.Errorf("invalid character set: '%c' >= '%c'", r, next))
 		}
 		r = next
 	}
@@ -113,13 +138,72 @@ func ValidateCharacterSet(set CharacterSet) error {
 			break
 		}
 		if r <= prev {
-			return fmt.Errorf("invalid character set: '%c' <= '%c'", r, prev)
+			return withCode(CodeInvalidCharacterSet, fmt.Errorf("invalid character set: '%c' <= '%c'", r, prev))
 		}
 		r = prev
 	}
 	return nil
 }
 
+// ValidateUTF8Order checks if the character set sorts in the same order when its characters are compared
+// as UTF-8 encoded bytes, which is how stores such as DynamoDB compare string sort keys.
+// UTF-8 preserves the order of code points, so the set sorts correctly if it is valid by ValidateCharacterSet and
+// all characters can be encoded in UTF-8.
+func ValidateUTF8Order(set CharacterSet) error {
+	if err := ValidateCharacterSet(set); err != nil {
+		return err
+	}
+	for r, ok := set.Min(), true; ok; r, ok = set.Next(r) {
+		if !utf8.ValidRune(r) {
+			return withCode(CodeInvalidCharacterSet, fmt.Errorf("invalid character set: %U is not a valid UTF-8 character", r))
+		}
+	}
+	return nil
+}
+
+// ValidateURLSafe checks if keys of the character set can be embedded in URL paths and queries without escaping,
+// which is the case when all characters are unreserved characters of RFC 3986.
+func ValidateURLSafe(set CharacterSet) error {
+	for r, ok := set.Min(), true; ok; r, ok = set.Next(r) {
+		if !isURLUnreserved(r) {
+			return withCode(CodeInvalidCharacterSet, fmt.Errorf("invalid character set: '%c' is not URL-safe", r))
+		}
+	}
+	return nil
+}
+
+// ValidateBucketURLSafe checks if keys of the Bucket, including the separator, can be embedded in URL paths
+// and queries without escaping.
+func ValidateBucketURLSafe(b *Bucket) error {
+	for _, r := range b.separator {
+		if !isURLUnreserved(r) {
+			return withCode(CodeInvalidArgument, fmt.Errorf("invalid separator: '%c' is not URL-safe", r))
+		}
+	}
+	return ValidateURLSafe(b.generator.characterSet)
+}
+
+func isURLUnreserved(r rune) bool {
+	return 'a' <= r && r <= 'z' || 'A' <= r && r <= 'Z' || '0' <= r && r <= '9' || strings.ContainsRune("-._~", r)
+}
+
+// ErrCaseCollision is returned when keys differ only by letter case, which are equal under case-insensitive collations.
+var ErrCaseCollision = withCode(CodeCaseCollision, errors.New("case collision"))
+
+// ValidateCaseInsensitive checks if the character set has no two characters that differ only by letter case,
+// so that its keys are distinct and sorted under case-insensitive collations, such as the default MySQL collations.
+func ValidateCaseInsensitive(set CharacterSet) error {
+	folded := map[rune]rune{}
+	for r, ok := set.Min(), true; ok; r, ok = set.Next(r) {
+		f := unicode.ToLower(r)
+		if other, ok := folded[f]; ok {
+			return fmt.Errorf("invalid character set: %w: '%c' and '%c'", ErrCaseCollision, other, r)
+		}
+		folded[f] = r
+	}
+	return nil
+}
+
 // Key represents a lexicographically sortable string key.
 type Key string
 
@@ -139,143 +223,344 @@ func (k BucketKey) String() string {
 type Generator struct {
 	characterSet CharacterSet
 	initial      string
+	// defaulted reports whether initial is the default of the character set rather than given by WithInitial.
+	defaulted    bool
+	rand         *rand.Rand
+	denseRatio   float64
+	midpoint     MidpointStrategy
+	minLength    int
+	foldCase     bool
+	foldCaseErr  error
+	suffixLength int
+	pinnedTop    Key
+	pinnedBottom Key
+	hmacSecret   []byte
+	hmacLength   int
+	transformer  KeyTransformer
+	growth       *atomic.Uint64
+	algorithm    Algorithm
+	fixedWidth   int
+	observer     func(Key, error)
+	keyPrefix    string
 }
 
 var (
 	// DefaultCharacterSet is the standard character set used for key generation.
-	DefaultCharacterSet = mustCharacterSet(NewASCIICharacterSet("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"))
+	DefaultCharacterSet CharacterSet = newCharacterSet("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz")
+	// URLSafeCharacterSet is a character set whose keys can be embedded in URLs without escaping.
+	// It excludes '.' so that no key can be a "." or ".." path segment.
+	URLSafeCharacterSet CharacterSet = newCharacterSet("-0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ_abcdefghijklmnopqrstuvwxyz~")
 )
 
 func defaultInitial(cs CharacterSet) string {
 	return strings.Repeat(string(cs.Mid(cs.Min(), cs.Max())), 6)
 }
 
-func mustCharacterSet(set CharacterSet, err error) CharacterSet {
-	if err != nil {
-		panic(err)
-	}
-	return set
-}
-
 // NewGenerator creates a new Generator with the specified options.
 func NewGenerator(opts ...GeneratorOption) *Generator {
 	g := &Generator{
 		DefaultCharacterSet,
 		"",
+		false,
+		rand.New(runtimeSource{}),
+		0,
+		CenteredMidpoint,
+		0,
+		false,
+		nil,
+		0,
+		"",
+		"",
+		nil,
+		0,
+		nil,
+		nil,
+		HeuristicAlgorithm,
+		0,
+		nil,
+		"",
 	}
 	for _, opt := range opts {
 		opt(g)
 	}
-	if g.initial == "" {
+	g.setDefaultInitial()
+	g.validateFoldCase()
+	return g
+}
+
+// setDefaultInitial sets the default initial key of the character set unless WithInitial is specified.
+func (g *Generator) setDefaultInitial() {
+	g.defaulted = g.initial == ""
+	if g.defaulted {
 		g.initial = defaultInitial(g.characterSet)
 	}
-	return g
+}
+
+// validateFoldCase caches the result of ValidateCaseInsensitive for WithCaseInsensitive,
+// so that Between does not validate the character set on every call.
+func (g *Generator) validateFoldCase() {
+	g.foldCaseErr = nil
+	if g.foldCase {
+		g.foldCaseErr = ValidateCaseInsensitive(g.characterSet)
+	}
+}
+
+// CharacterSet returns the character set used by the Generator.
+func (g *Generator) CharacterSet() CharacterSet {
+	return g.characterSet
+}
+
+// MinBoundary returns a key that sorts before any key generated by the Generator, which is never generated.
+// It consists of the character right before the min character of the set, or is empty if there is none.
+// It is useful as an exclusive lower bound of range queries.
+func (g *Generator) MinBoundary() Key {
+	if g.characterSet.Min() == 0 {
+		return ""
+	}
+	return Key(g.characterSet.Min() - 1)
+}
+
+// MaxBoundary returns a key that sorts after any key generated by the Generator, which is never generated.
+// It consists of the character right after the max character of the set.
+// It is useful as an exclusive upper bound of range queries.
+func (g *Generator) MaxBoundary() Key {
+	return Key(g.characterSet.Max() + 1)
+}
+
+// Clone creates a new Generator with the same configuration as g, overridden by the specified options.
+// The initial key given by WithInitial is kept unless WithInitial is specified again, while the default initial key
+// follows the character set of the clone.
+func (g *Generator) Clone(opts ...GeneratorOption) *Generator {
+	clone := *g
+	if clone.defaulted {
+		clone.initial = ""
+	}
+	for _, opt := range opts {
+		opt(&clone)
+	}
+	clone.setDefaultInitial()
+	clone.validateFoldCase()
+	return &clone
 }
 
 // Between generates a key that comes between the prevKey and nextKey keys.
-func (g *Generator) Between(prevKey, nextKey Key) (Key, error) {
+//
+// If WithDenseRatio is set and the generated key is too long, Between returns the key together with
+// an error wrapping ErrKeySpaceDense. The key is still valid and can be used.
+//
+// Errors are returned as *BetweenError. A panic in the configured CharacterSet, MidpointStrategy or Algorithm,
+// such as one caused by a nil option, is returned as *InvariantError.
+func (g *Generator) Between(prevKey, nextKey Key) (key Key, err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			key, err = "", &BetweenError{prevKey, nextKey, "", &InvariantError{v}}
+		}
+	}()
+	key, err = g.betweenKeys(prevKey, nextKey)
+	if err != nil {
+		err = &BetweenError{prevKey, nextKey, string(characterSetRunes(g.characterSet)), err}
+	}
+	return key, err
+}
+
+func (g *Generator) betweenKeys(prevKey, nextKey Key) (Key, error) {
+	if g.observer != nil {
+		unobserved := *g
+		unobserved.observer = nil
+		key, err := unobserved.betweenKeys(prevKey, nextKey)
+		g.observer(key, err)
+		return key, err
+	}
+	if g.keyPrefix != "" {
+		return g.betweenPrefixed(prevKey, nextKey)
+	}
+	if g.transformer != nil {
+		return g.transform(prevKey, nextKey, (*Generator).betweenKeys)
+	}
+	if g.foldCase {
+		if g.foldCaseErr != nil {
+			return "", g.foldCaseErr
+		}
+		if prevKey != "" && strings.EqualFold(string(prevKey), string(nextKey)) {
+			return "", fmt.Errorf("%w: %q and %q", ErrCaseCollision, prevKey, nextKey)
+		}
+	}
+	prevKey, nextKey = g.excludePinned(prevKey, nextKey)
+	return g.generate(prevKey, nextKey)
+}
+
+// generate generates a key between the keys without checking the keys.
+func (g *Generator) generate(prevKey, nextKey Key) (Key, error) {
+	if g.hmacSecret != nil {
+		return g.betweenSigned(prevKey, nextKey)
+	}
+	if g.suffixLength > 0 {
+		return g.betweenWithSuffix(prevKey, nextKey)
+	}
+	return g.betweenPadded(prevKey, nextKey)
+}
+
+// betweenPadded generates a key between the keys applying the min key length and the dense ratio.
+func (g *Generator) betweenPadded(prevKey, nextKey Key) (Key, error) {
+	key, err := g.between(prevKey, nextKey)
+	if err != nil {
+		return "", err
+	}
+	if len([]rune(key)) < g.minLength {
+		key, err = g.pad(key, nextKey)
+		if err != nil {
+			return "", err
+		}
+	}
+	if g.denseRatio > 0 {
+		limit := g.denseRatio * float64(len([]rune(g.initial)))
+		if n := len([]rune(key)); float64(n) > limit {
+			return key, fmt.Errorf("%w: key length %d exceeds %g times the initial key length", ErrKeySpaceDense, n, g.denseRatio)
+		}
+	}
+	return key, nil
+}
+
+// pad appends characters to key, which comes before nextKey, up to the min key length.
+func (g *Generator) pad(key, nextKey Key) (Key, error) {
+	mid := g.midpoint.Midpoint(g.characterSet, g.characterSet.Min(), g.characterSet.Max(), g.rand)
+	padded := key + Key(strings.Repeat(string(mid), g.minLength-len([]rune(key))))
+	if nextKey == "" || padded < nextKey {
+		return padded, nil
+	}
+	// key is a prefix of nextKey. Find another key of the length.
+	padded, ok, err := centerWithLength(newKeyDigits(g.characterSet), key, nextKey, g.minLength)
+	if err != nil || !ok {
+		return key, err
+	}
+	return padded, nil
+}
+
+// ErrKeySpaceDense is returned along with a valid key when keys are growing too long, so rebalancing should be scheduled.
+var ErrKeySpaceDense = withCode(CodeKeySpaceDense, errors.New("key space dense"))
+
+func (g *Generator) between(prevKey, nextKey Key) (Key, error) {
+	if g.fixedWidth > 0 {
+		return g.betweenFixedWidth(prevKey, nextKey)
+	}
 	if prevKey == "" && nextKey == "" {
 		return Key(g.initial), nil
 	}
+	if m, ok := g.midpoint.(*alternatingMidpoint); ok {
+		// Select every character of the key with the same strategy, so that keys alternate.
+		fixed := *g
+		fixed.midpoint = m.next()
+		return fixed.algorithm.Between(&fixed, prevKey, nextKey)
+	}
+	return g.algorithm.Between(g, prevKey, nextKey)
+}
 
+// betweenHeuristic generates a key between the keys by splicing characters, which is HeuristicAlgorithm.
+func (g *Generator) betweenHeuristic(prevKey, nextKey Key) (Key, error) {
 	if nextKey == "" {
-		runes := []rune(prevKey)
-		n := len(runes)
-		for i := n - 1; i >= 0; i-- {
-			charToIncrement := runes[i]
-			incrementedChar, ok := g.characterSet.Next(charToIncrement)
-			if ok {
-				runes[i] = incrementedChar
-				for j := i + 1; j < n; j++ {
-					runes[j] = g.characterSet.Min()
-				}
-				return Key(runes), nil
-			}
-		}
-		// If the min character is used here, generating a key between prevKey and generated key will be impossible.
-		// For example, if prevKey was "000" and generated key was "0000", no key can be generated between them.
-		// If the generated key is "0001", a key between "000" and "0001" can be "00004".
-		nextToMin, ok := g.characterSet.Next(g.characterSet.Min())
-		if !ok {
-			return "", fmt.Errorf("next character of min character '%c' not found: %q - %q", g.characterSet.Min(), prevKey, nextKey)
-		}
-		return Key(string(prevKey) + string(nextToMin)), nil
+		return g.after(string(prevKey))
 	}
 
 	if prevKey == "" {
-		runes := []rune(nextKey)
-		n := len(runes)
-		for i := n - 1; i >= 0; i-- {
-			charToDecrement := runes[i]
-			decrementedChar, ok := g.characterSet.Prev(charToDecrement)
-			if ok {
-				runes[i] = decrementedChar
-				for j := i + 1; j < n; j++ {
-					runes[j] = g.characterSet.Max()
-				}
-				return Key(runes), nil
-			}
-		}
-		return "", fmt.Errorf("cannot generate key strictly before %q as it (or its prefix) consists of all min characters from the set: %q - %q", nextKey, prevKey, nextKey)
+		return g.before(string(nextKey))
 	}
 
 	if prevKey > nextKey {
-		return "", fmt.Errorf("prevKey (%q) must be strictly less than nextKey (%q)", prevKey, nextKey)
+		return "", withCode(CodeOrderViolation, fmt.Errorf("prevKey (%q) must be strictly less than nextKey (%q)", prevKey, nextKey))
 	}
 
-	prevRunes := []rune(string(prevKey))
-	nextRunes := []rune(string(nextKey))
-	switch n := len(prevRunes) - len(nextRunes); {
-	case n > 0:
-		for i := 0; i < n; i++ {
-			nextRunes = append(nextRunes, g.characterSet.Min())
-		}
-	case n < 0:
-		for i := 0; i < -n; i++ {
-			prevRunes = append(prevRunes, g.characterSet.Min())
+	return g.inside(string(prevKey), string(nextKey)), nil
+}
+
+// after generates a key after prev by incrementing its last character that is not the max character.
+func (g *Generator) after(prev string) (Key, error) {
+	for end := len(prev); end > 0; {
+		r, size := utf8.DecodeLastRuneInString(prev[:end])
+		if next, ok := g.characterSet.Next(r); ok {
+			return buildKey(prev[:end-size], 0, 0, next, utf8.RuneCountInString(prev[end:]), g.characterSet.Min()), nil
 		}
+		end -= size
+	}
+	// If the min character is used here, generating a key between prevKey and generated key will be impossible.
+	// For example, if prevKey was "000" and generated key was "0000", no key can be generated between them.
+	// If the generated key is "0001", a key between "000" and "0001" can be "00004".
+	nextToMin, ok := g.characterSet.Next(g.characterSet.Min())
+	if !ok {
+		return "", withCode(CodeKeySpaceExhausted, fmt.Errorf("next character of min character '%c' not found: %q - %q", g.characterSet.Min(), prev, ""))
 	}
+	return buildKey(prev, 0, 0, nextToMin, 0, 0), nil
+}
 
-	mid := g.characterSet.Mid(g.characterSet.Min(), g.characterSet.Max())
-	for i, prevChar := range prevRunes {
-		nextChar := nextRunes[i]
-		if prevChar == nextChar {
-			continue
+// before generates a key before next by decrementing its last character that is not the min character.
+func (g *Generator) before(next string) (Key, error) {
+	for end := len(next); end > 0; {
+		r, size := utf8.DecodeLastRuneInString(next[:end])
+		if prev, ok := g.characterSet.Prev(r); ok {
+			return buildKey(next[:end-size], 0, 0, prev, utf8.RuneCountInString(next[end:]), g.characterSet.Max()), nil
 		}
-		next := g.characterSet.Mid(prevChar, nextChar)
-
-		if next > prevChar {
-			result := append(prevRunes[:i], next)
-			for j := i + 1; j < len(prevRunes); j++ {
-				result = append(result, mid)
-			}
-			return Key(result), nil
+		end -= size
+	}
+	return "", withCode(CodeKeySpaceExhausted, fmt.Errorf("cannot generate key strictly before %q as it (or its prefix) consists of all min characters from the set: %q - %q", next, "", next))
+}
+
+// inside generates a key between prev and next, comparing them as if the shorter one were padded with the min
+// character to the same length.
+func (g *Generator) inside(prev, next string) Key {
+	minChar := g.characterSet.Min()
+	mid := g.midpoint.Midpoint(g.characterSet, minChar, g.characterSet.Max(), g.rand)
+	n := max(utf8.RuneCountInString(prev), utf8.RuneCountInString(next))
+
+	// pi and ni are the byte offsets of the i-th character, and pn and nn are the numbers of characters
+	// consumed from prev and next. order is the comparison of next[:i] and prev[:i].
+	var pi, ni, pn, nn, order int
+	for i := 0; i < n; i++ {
+		prevChar, prevSize := minChar, 0
+		if pi < len(prev) {
+			prevChar, prevSize = utf8.DecodeRuneInString(prev[pi:])
+		}
+		nextChar, nextSize := minChar, 0
+		if ni < len(next) {
+			nextChar, nextSize = utf8.DecodeRuneInString(next[ni:])
 		}
-		if next < nextChar && runesGreaterThan(nextRunes[:i], prevRunes[:i]) {
-			result := append(nextRunes[:i], next)
-			for j := i + 1; j < len(prevRunes); j++ {
-				result = append(result, mid)
+		if prevChar != nextChar {
+			c := g.midpoint.Midpoint(g.characterSet, prevChar, nextChar, g.rand)
+			if c > prevChar {
+				return buildKey(prev[:pi], i-pn, minChar, c, n-i-1, mid)
 			}
-			return Key(result), nil
+			if c < nextChar && order > 0 {
+				return buildKey(next[:ni], i-nn, minChar, c, n-i-1, mid)
+			}
+			if order == 0 {
+				order = cmp.Compare(nextChar, prevChar)
+			}
+		}
+		if prevSize > 0 {
+			pi, pn = pi+prevSize, pn+1
+		}
+		if nextSize > 0 {
+			ni, nn = ni+nextSize, nn+1
 		}
 	}
-
-	return Key(prevRunes) + Key(mid), nil
+	if g.growth != nil {
+		mid = g.growthChar()
+	}
+	return buildKey(prev, n-pn, minChar, mid, 0, 0)
 }
 
-func runesGreaterThan(a, b []rune) bool {
-	if len(a) != len(b) {
-		panic("runesGreaterThan: lengths of a and b must be equal")
+// buildKey returns prefix followed by padding repeated n times, c and fill repeated m times
+// with a single allocation.
+func buildKey(prefix string, n int, padding rune, c rune, m int, fill rune) Key {
+	var sb strings.Builder
+	sb.Grow(len(prefix) + n*utf8.RuneLen(padding) + utf8.RuneLen(c) + m*utf8.RuneLen(fill))
+	sb.WriteString(prefix)
+	for range n {
+		sb.WriteRune(padding)
 	}
-	for i := 0; i < len(a); i++ {
-		if a[i] > b[i] {
-			return true
-		}
-		if a[i] < b[i] {
-			return false
-		}
+	sb.WriteRune(c)
+	for range m {
+		sb.WriteRune(fill)
 	}
-	return false
+	return Key(sb.String())
 }
 
 // Next generates a key that comes after the given key.
@@ -293,15 +578,77 @@ func (g *Generator) Initial() (Key, error) {
 	return g.Between("", "")
 }
 
+// InsertAt generates a key to insert into the sorted keys at the index, i.e. the key comes after keys[index-1]
+// and before keys[index]. An index of 0 inserts before the first key and len(keys) after the last key.
+func (g *Generator) InsertAt(keys []Key, index int) (Key, error) {
+	return insertAt(keys, index, g.Between)
+}
+
+func insertAt[K ~string](keys []K, index int, between func(prevKey, nextKey K) (K, error)) (K, error) {
+	if index < 0 || index > len(keys) {
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("index %d is out of range [0, %d]", index, len(keys)))
+	}
+	var prevKey, nextKey K
+	if index > 0 {
+		prevKey = keys[index-1]
+	}
+	if index < len(keys) {
+		nextKey = keys[index]
+	}
+	return between(prevKey, nextKey)
+}
+
+// DefaultGenerator is the Generator used by Between, Next, Prev and Initial.
+// It uses DefaultCharacterSet and the default initial key.
+var DefaultGenerator = NewGenerator()
+
+// Between generates a key that comes between the prevKey and nextKey keys using DefaultGenerator.
+func Between(prevKey, nextKey Key) (Key, error) {
+	return DefaultGenerator.Between(prevKey, nextKey)
+}
+
+// Next generates a key that comes after the given key using DefaultGenerator.
+func Next(key Key) (Key, error) {
+	return DefaultGenerator.Next(key)
+}
+
+// Prev generates a key that comes before the given key using DefaultGenerator.
+func Prev(key Key) (Key, error) {
+	return DefaultGenerator.Prev(key)
+}
+
+// Initial generates the initial key using DefaultGenerator.
+func Initial() (Key, error) {
+	return DefaultGenerator.Initial()
+}
+
+// BetweenIn generates a key that comes between the prevKey and nextKey keys using the character set,
+// without holding a Generator. It is the same as Between of a Generator with the character set and default options.
+func BetweenIn(set CharacterSet, prevKey, nextKey Key) (Key, error) {
+	return NewGenerator(WithCharacterSet(set)).Between(prevKey, nextKey)
+}
+
+// NextIn generates a key that comes after the given key using the character set.
+func NextIn(set CharacterSet, key Key) (Key, error) {
+	return BetweenIn(set, key, "")
+}
+
+// PrevIn generates a key that comes before the given key using the character set.
+func PrevIn(set CharacterSet, key Key) (Key, error) {
+	return BetweenIn(set, "", key)
+}
+
 type generatorOption func(*Generator)
 
 // GeneratorOption is a option for configuring the Generator.
 type GeneratorOption generatorOption
 
-// WithCharacterSet returns a GeneratorOption that sets the character set used by the Generator.
+// WithCharacterSet returns a GeneratorOption that sets the character set used by the Generator. A nil set is ignored.
 func WithCharacterSet(set CharacterSet) GeneratorOption {
 	return func(g *Generator) {
-		g.characterSet = set
+		if set != nil {
+			g.characterSet = set
+		}
 	}
 }
 
@@ -312,19 +659,64 @@ func WithInitial(initial string) GeneratorOption {
 	}
 }
 
+// WithRandSource returns a GeneratorOption that sets the source of randomness used by the Generator.
+// Generators with the same source state generate the same sequence of keys, which is useful for tests and replays.
+// The source must be safe for concurrent use if the Generator is used concurrently.
+func WithRandSource(src rand.Source) GeneratorOption {
+	return func(g *Generator) {
+		g.rand = rand.New(src)
+	}
+}
+
+// WithDenseRatio returns a GeneratorOption that makes Between report ErrKeySpaceDense
+// when a generated key is longer than ratio times the length of the initial key.
+func WithDenseRatio(ratio float64) GeneratorOption {
+	return func(g *Generator) {
+		g.denseRatio = ratio
+	}
+}
+
+// WithMinKeyLength returns a GeneratorOption that pads generated keys to at least n characters,
+// so that keys have a uniform width.
+func WithMinKeyLength(n int) GeneratorOption {
+	return func(g *Generator) {
+		g.minLength = n
+	}
+}
+
+// WithCaseInsensitive returns a GeneratorOption for keys stored with a case-insensitive collation.
+// Between returns an error wrapping ErrCaseCollision if the character set has characters that differ only by
+// letter case, or if prevKey and nextKey differ only by letter case.
+func WithCaseInsensitive() GeneratorOption {
+	return func(g *Generator) {
+		g.foldCase = true
+	}
+}
+
+// runtimeSource is a rand.Source backed by the top-level functions of math/rand/v2, which are safe for concurrent use.
+type runtimeSource struct{}
+
+func (runtimeSource) Uint64() uint64 {
+	return rand.Uint64()
+}
+
 // Bucket represents a namespace for keys, allowing separate key sequences in different buckets.
 type Bucket struct {
 	defaultPrefix string
-	separator     rune
+	separator     string
 	generator     *Generator
+	initialFunc   func(bucket string) string
+	crossBucket   bool
 }
 
 // NewBucket creates a new Bucket with the specified name and Generator.
 func NewBucket(opts ...BucketOption) *Bucket {
 	b := &Bucket{
 		"0",
-		'|',
+		string(DefaultSeparator),
 		nil,
+		nil,
+		false,
 	}
 	for _, opt := range opts {
 		opt(b)
@@ -335,14 +727,35 @@ func NewBucket(opts ...BucketOption) *Bucket {
 	return b
 }
 
+// Separator returns the separator between the bucket and the key of BucketKey.
+// If the separator consists of multiple characters, the first one is returned. Use SeparatorString to get all.
+func (b *Bucket) Separator() rune {
+	r, _ := utf8.DecodeRuneInString(b.separator)
+	return r
+}
+
+// SeparatorString returns the separator between the bucket and the key of BucketKey.
+func (b *Bucket) SeparatorString() string {
+	return b.separator
+}
+
+// DefaultPrefix returns the bucket used for the initial key generation.
+func (b *Bucket) DefaultPrefix() string {
+	return b.defaultPrefix
+}
+
 // Between generates a key that comes between the prev and next keys within this bucket.
 func (b *Bucket) Between(prev, next BucketKey) (BucketKey, error) {
+	if prev == "" && next == "" {
+		return b.InitialIn("")
+	}
+
 	var prefix string
 	var prevKey Key
 	if prev != "" {
 		prevBucket, key := b.SplitBucketKey(prev)
 		if prevBucket == "" {
-			return "", errors.New("prev key is not in format of bucket key")
+			return "", withCode(CodeInvalidKey, errors.New("prev key is not in format of bucket key"))
 		}
 		prevKey = key
 		prefix = prevBucket
@@ -351,16 +764,26 @@ func (b *Bucket) Between(prev, next BucketKey) (BucketKey, error) {
 	if next != "" {
 		nextBucket, key := b.SplitBucketKey(next)
 		if nextBucket == "" {
-			return "", errors.New("next key is not in format of bucket key")
+			return "", withCode(CodeInvalidKey, errors.New("next key is not in format of bucket key"))
 		}
 		if prefix != "" && prefix != nextBucket {
-			return "", fmt.Errorf("%w: %q != %q", ErrBucketMismatch, prefix, nextBucket)
+			if !b.crossBucket {
+				return "", fmt.Errorf("%w: %q != %q", ErrBucketMismatch, prefix, nextBucket)
+			}
+			if prefix > nextBucket {
+				err := withCode(CodeOrderViolation, fmt.Errorf("prev bucket (%q) must be less than next bucket (%q)", prefix, nextBucket))
+				return "", &BetweenError{Key(prev), Key(next), string(characterSetRunes(b.generator.characterSet)), err}
+			}
+			prevKey = ""
 		}
 		nextKey = key
 		prefix = nextBucket
 	}
 
 	k, err := b.generator.Between(prevKey, nextKey)
+	if errors.Is(err, ErrKeySpaceDense) {
+		return b.createBucketKey(prefix, k), err
+	}
 	if err != nil {
 		return "", err
 	}
@@ -382,10 +805,38 @@ func (b *Bucket) Initial() (BucketKey, error) {
 	return b.Between("", "")
 }
 
-var ErrBucketMismatch = errors.New("bucket mismatch")
+// InitialIn generates the initial key in the specified bucket.
+// It returns an error if the key returned by the function of WithInitialFunc is not in the character set.
+func (b *Bucket) InitialIn(bucket string) (BucketKey, error) {
+	if bucket == "" {
+		bucket = b.defaultPrefix
+	}
+	if err := b.validateLabel(bucket); err != nil {
+		return "", err
+	}
+	if b.initialFunc != nil {
+		if initial := Key(b.initialFunc(bucket)); initial != "" {
+			if _, err := newKeyDigits(b.generator.characterSet).toInt(initial, len([]rune(initial))); err != nil {
+				return "", err
+			}
+			k, err := b.generator.finishKey(initial)
+			if err != nil {
+				return "", err
+			}
+			return b.createBucketKey(bucket, k), nil
+		}
+	}
+	k, err := b.generator.Initial()
+	if err != nil {
+		return "", err
+	}
+	return b.createBucketKey(bucket, k), nil
+}
+
+var ErrBucketMismatch = withCode(CodeBucketMismatch, errors.New("bucket mismatch"))
 
 func (b *Bucket) SplitBucketKey(key BucketKey) (string, Key) {
-	parts := strings.SplitN(string(key), string(b.separator), 2)
+	parts := strings.SplitN(string(key), b.separator, 2)
 	if len(parts) != 2 {
 		return "", ""
 	}
@@ -396,7 +847,54 @@ func (b *Bucket) createBucketKey(bucket string, key Key) BucketKey {
 	if bucket == "" {
 		bucket = b.defaultPrefix
 	}
-	return BucketKey(fmt.Sprintf("%s%c%s", bucket, b.separator, key))
+	return BucketKey(bucket + b.separator + string(key))
+}
+
+// ValidateBucketSeparator checks if the separator of the Bucket can separate the bucket and the key of every
+// BucketKey, i.e. it is not empty, cannot appear inside keys of the Generator and does not appear in the
+// default prefix.
+func ValidateBucketSeparator(b *Bucket) error {
+	if b.separator == "" {
+		return withCode(CodeInvalidArgument, errors.New("invalid separator: separator must not be empty"))
+	}
+	runes := characterSetRunes(b.generator.characterSet)
+	if !strings.ContainsFunc(b.separator, func(r rune) bool { return !slices.Contains(runes, r) }) {
+		return withCode(CodeInvalidArgument, fmt.Errorf("invalid separator: %q can appear inside keys", b.separator))
+	}
+	return b.validateLabel(b.defaultPrefix)
+}
+
+// validateLabel checks if the BucketKey of the bucket is split at the end of the bucket.
+func (b *Bucket) validateLabel(bucket string) error {
+	if strings.Index(bucket+b.separator, b.separator) != len(bucket) {
+		return withCode(CodeInvalidArgument, fmt.Errorf("invalid bucket %q: it contains the separator %q", bucket, b.separator))
+	}
+	return nil
+}
+
+// Validate checks the BucketKey against the configuration of the Bucket: the label consists of characters of
+// the character set, the separator of the Bucket is between a non-empty label and a non-empty rank, and the rank
+// consists of characters of the character set. The error is a *BucketKeyError telling which part is invalid.
+func (k BucketKey) Validate(b *Bucket) error {
+	label, rank, ok := strings.Cut(string(k), b.separator)
+	switch {
+	case !ok:
+		return &BucketKeyError{k, BucketKeySeparator, fmt.Errorf("%q not found", b.separator)}
+	case label == "":
+		return &BucketKeyError{k, BucketKeySeparator, fmt.Errorf("%q is at the start", b.separator)}
+	case rank == "":
+		return &BucketKeyError{k, BucketKeySeparator, fmt.Errorf("%q is at the end", b.separator)}
+	}
+	chars := characterSetRunes(b.generator.characterSet)
+	if i := strings.IndexFunc(label, func(r rune) bool { return !slices.Contains(chars, r) }); i >= 0 {
+		r, _ := utf8.DecodeRuneInString(label[i:])
+		return &BucketKeyError{k, BucketKeyLabel, fmt.Errorf("'%c' is not in the character set", r)}
+	}
+	if i := strings.IndexFunc(rank, func(r rune) bool { return !slices.Contains(chars, r) }); i >= 0 {
+		r, _ := utf8.DecodeRuneInString(rank[i:])
+		return &BucketKeyError{k, BucketKeyRank, fmt.Errorf("'%c' is not in the character set", r)}
+	}
+	return nil
 }
 
 type bucketOption func(*Bucket)
@@ -407,7 +905,15 @@ type BucketOption bucketOption
 // WithSeparator returns a BucketOption that sets the separator of BucketKey.
 func WithSeparator(sep rune) BucketOption {
 	return func(g *Bucket) {
-		g.separator = sep
+		g.separator = string(sep)
+	}
+}
+
+// WithSeparatorString returns a BucketOption that sets the separator of BucketKey consisting of multiple
+// characters, e.g. "::". Use ValidateBucketSeparator to check the separator.
+func WithSeparatorString(sep string) BucketOption {
+	return func(b *Bucket) {
+		b.separator = sep
 	}
 }
 
@@ -425,3 +931,22 @@ func WithDefaultPrefix(prefix string) BucketOption {
 		b.defaultPrefix = prefix
 	}
 }
+
+// WithInitialFunc returns a BucketOption that sets a function returning the initial key of each bucket.
+// If the function returns an empty string, the initial key of the Generator is used.
+// The key must consist of characters of the character set, and it is prefixed and signed as configured by
+// WithKeyPrefix and WithHMAC like the keys generated by the Generator.
+func WithInitialFunc(f func(bucket string) string) BucketOption {
+	return func(b *Bucket) {
+		b.initialFunc = f
+	}
+}
+
+// WithCrossBucket returns a BucketOption that allows prev and next keys of Between to be in different buckets,
+// which happens while keys are being moved to another bucket. The key is generated in the bucket of the next
+// key before the next key, instead of returning ErrBucketMismatch.
+func WithCrossBucket() BucketOption {
+	return func(b *Bucket) {
+		b.crossBucket = true
+	}
+}
@@ -3,6 +3,8 @@ package lexorank
 import (
 	"errors"
 	"fmt"
+	"math/rand/v2"
+	"slices"
 	"strings"
 	"testing"
 )
@@ -15,6 +17,112 @@ func TestNewASCIICharacterSet(t *testing.T) {
 	noError(t, err)
 }
 
+func TestNewASCIICharacterSet_Invalid(t *testing.T) {
+	for _, set := range []string{"", "aa", "abca", "aé"} {
+		if _, err := NewASCIICharacterSet(set); ErrorCode(err) != CodeInvalidCharacterSet {
+			t.Fatalf("%q: expected invalid character set, got %v", set, err)
+		}
+	}
+}
+
+func TestValidateUTF8Order(t *testing.T) {
+	noError(t, ValidateUTF8Order(DefaultCharacterSet))
+	// Characters of different encoded lengths sort by their bytes as by their code points.
+	noError(t, ValidateUTF8Order(runeCharacterSet{'a', 'é', '日', '🙂'}))
+
+	for _, set := range []runeCharacterSet{
+		{'a', 0xD800},
+		{'b', 'a'},
+		{'日', 'é'},
+	} {
+		if err := ValidateUTF8Order(set); ErrorCode(err) != CodeInvalidCharacterSet {
+			t.Fatalf("%q: expected CodeInvalidCharacterSet, got %v", []rune(set), err)
+		}
+	}
+}
+
+func TestValidateURLSafe(t *testing.T) {
+	noError(t, ValidateURLSafe(DefaultCharacterSet))
+	noError(t, ValidateURLSafe(URLSafeCharacterSet))
+	noError(t, ValidateCharacterSet(URLSafeCharacterSet))
+
+	charSet, err := NewASCIICharacterSet("0123456789/")
+	noError(t, err)
+	if err := ValidateURLSafe(charSet); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+
+	if err := ValidateBucketURLSafe(NewBucket()); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+	noError(t, ValidateBucketURLSafe(NewBucket(WithSeparator('~'))))
+}
+
+func TestValidateCaseInsensitive(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789abcdefghijklmnopqrstuvwxyz")
+	noError(t, err)
+	noError(t, ValidateCaseInsensitive(charSet))
+
+	err = ValidateCaseInsensitive(DefaultCharacterSet)
+	if !errors.Is(err, ErrCaseCollision) {
+		t.Fatalf("expected ErrCaseCollision, got %v", err)
+	}
+}
+
+func TestWithCaseInsensitive(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZ")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithCaseInsensitive())
+	key, err := g.Between("A", "C")
+	noError(t, err)
+	equalKey(t, key, "B")
+
+	if _, err := NewGenerator(WithCaseInsensitive()).Initial(); !errors.Is(err, ErrCaseCollision) {
+		t.Fatalf("expected ErrCaseCollision, got %v", err)
+	}
+
+	mixed, err := NewASCIICharacterSet("ABCabc")
+	noError(t, err)
+	if _, err := NewGenerator(WithCharacterSet(mixed), WithCaseInsensitive()).Between("a", "b"); !errors.Is(err, ErrCaseCollision) {
+		t.Fatalf("expected ErrCaseCollision, got %v", err)
+	}
+
+	// The validation follows the character set of clones.
+	if _, err := g.Clone(WithCharacterSet(mixed)).Between("a", "c"); !errors.Is(err, ErrCaseCollision) {
+		t.Fatalf("expected ErrCaseCollision, got %v", err)
+	}
+	_, err = NewGenerator(WithCaseInsensitive()).Clone(WithCharacterSet(charSet)).Initial()
+	noError(t, err)
+}
+
+// runeCharacterSet is a CharacterSet of arbitrary runes for tests.
+type runeCharacterSet []rune
+
+func (c runeCharacterSet) Min() rune { return c[0] }
+func (c runeCharacterSet) Max() rune { return c[len(c)-1] }
+func (c runeCharacterSet) Next(r rune) (rune, bool) {
+	i := slices.Index(c, r)
+	if i == len(c)-1 {
+		return 0, false
+	}
+	return c[i+1], true
+}
+func (c runeCharacterSet) Prev(r rune) (rune, bool) {
+	i := slices.Index(c, r)
+	if i == 0 {
+		return 0, false
+	}
+	return c[i-1], true
+}
+func (c runeCharacterSet) Mid(a, b rune) rune {
+	i, j := slices.Index(c, a), slices.Index(c, b)
+	if j < i {
+		j += len(c)
+	}
+	return c[(i+j)/2%len(c)]
+}
+
 func TestGenerator(t *testing.T) {
 	charSet, err := NewASCIICharacterSet("0123456789")
 	noError(t, err)
@@ -95,6 +203,166 @@ func TestGenerator(t *testing.T) {
 	})
 }
 
+func TestGenerator_Clone(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	base := NewGenerator(WithCharacterSet(charSet), WithInitial("555"))
+	clone := base.Clone(WithInitial("111"))
+
+	key, err := clone.Initial()
+	noError(t, err)
+	equalKey(t, key, "111")
+	key, err = clone.Between("699", "700")
+	noError(t, err)
+	equalKey(t, key, "6994")
+
+	key, err = base.Initial()
+	noError(t, err)
+	equalKey(t, key, "555")
+
+	// The default initial key follows the character set, while an explicit one is kept.
+	key, err = NewGenerator().Clone(WithCharacterSet(charSet)).Initial()
+	noError(t, err)
+	equalKey(t, key, "444444")
+	key, err = base.Clone(WithCharacterSet(DefaultCharacterSet)).Initial()
+	noError(t, err)
+	equalKey(t, key, "555")
+}
+
+func TestDefaultGenerator(t *testing.T) {
+	key, err := Initial()
+	noError(t, err)
+	equalKey(t, key, "UUUUUU")
+
+	key, err = Next("UUUUUU")
+	noError(t, err)
+	equalKey(t, key, "UUUUUV")
+
+	key, err = Prev("UUUUUU")
+	noError(t, err)
+	equalKey(t, key, "UUUUUT")
+
+	key, err = Between("a", "c")
+	noError(t, err)
+	equalKey(t, key, "b")
+}
+
+func TestBetweenIn(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	key, err := BetweenIn(charSet, "", "")
+	noError(t, err)
+	equalKey(t, key, "444444")
+
+	key, err = BetweenIn(charSet, "699", "700")
+	noError(t, err)
+	equalKey(t, key, "6994")
+
+	key, err = NextIn(charSet, "599")
+	noError(t, err)
+	equalKey(t, key, "600")
+
+	key, err = PrevIn(charSet, "700")
+	noError(t, err)
+	equalKey(t, key, "699")
+}
+
+func TestWithMinKeyLength(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithMinKeyLength(4))
+
+	for _, tt := range []struct {
+		prev Key
+		next Key
+		want Key
+	}{
+		{"", "", "5444"},
+		{"5444", "", "5445"},
+		{"", "5444", "5443"},
+		{"1", "9", "5444"},
+		{"12345", "12346", "123454"},
+	} {
+		t.Run(fmt.Sprintf("%s_%s", tt.prev, tt.next), func(t *testing.T) {
+			key, err := g.Between(tt.prev, tt.next)
+			noError(t, err)
+			equalKey(t, key, tt.want)
+			validateKey(t, key, tt.prev, tt.next)
+		})
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		testRecursive(t, g, "", "", 12)
+	})
+}
+
+func TestGenerator_Boundary(t *testing.T) {
+	g := NewGenerator()
+	equalKey(t, g.MinBoundary(), "/")
+	equalKey(t, g.MaxBoundary(), "{")
+
+	for _, key := range []Key{"0", "000001", "UUUUUU", "z", "zzzzzz"} {
+		if key <= g.MinBoundary() || key >= g.MaxBoundary() {
+			t.Fatalf("%s is not between the boundaries", key)
+		}
+	}
+}
+
+func TestWithDenseRatio(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("55"), WithDenseRatio(2))
+
+	key, err := g.Between("555", "556")
+	noError(t, err)
+	equalKey(t, key, "5554")
+
+	key, err = g.Between("5554", "5555")
+	if !errors.Is(err, ErrKeySpaceDense) {
+		t.Fatalf("expected ErrKeySpaceDense, got %v", err)
+	}
+	equalKey(t, key, "55544")
+}
+
+var betweenBenchmarks = []struct {
+	name string
+	prev Key
+	next Key
+}{
+	{"between", "UUUUUU", "UUUUUV"},
+	{"next", "UUUUUU", ""},
+	{"prev", "", "UUUUUU"},
+	{"long", "UUUUUUUUUUUUUUUUUUUU", "UUUUUUUUUUUUUUUUUUUV"},
+}
+
+func TestGenerator_Between_Allocs(t *testing.T) {
+	g := NewGenerator()
+	for _, bb := range betweenBenchmarks {
+		allocs := testing.AllocsPerRun(100, func() {
+			_, _ = g.Between(bb.prev, bb.next)
+		})
+		if allocs > 1 {
+			t.Errorf("%s: expected at most 1 allocation, got %v", bb.name, allocs)
+		}
+	}
+}
+
+func BenchmarkGenerator_Between(b *testing.B) {
+	g := NewGenerator()
+	for _, bb := range betweenBenchmarks {
+		b.Run(bb.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for b.Loop() {
+				_, _ = g.Between(bb.prev, bb.next)
+			}
+		})
+	}
+}
+
 func noError(t *testing.T, err error) {
 	if err != nil {
 		t.Fatalf("expected no error, got %v", err)
@@ -159,6 +427,15 @@ func TestBucket(t *testing.T) {
 		})
 	}
 
+	t.Run("getters", func(t *testing.T) {
+		if g.CharacterSet() != charSet {
+			t.Fatal("expected the character set of the generator")
+		}
+		if bucket.Separator() != '|' || bucket.DefaultPrefix() != "0" {
+			t.Fatalf("unexpected configuration: %c %s", bucket.Separator(), bucket.DefaultPrefix())
+		}
+	})
+
 	t.Run("error on bucket mismatch", func(t *testing.T) {
 		_, err := bucket.Between("0|555", "1|555")
 		if !errors.Is(err, ErrBucketMismatch) {
@@ -167,6 +444,100 @@ func TestBucket(t *testing.T) {
 	})
 }
 
+func TestWithCrossBucket(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	bucket := NewBucket(WithGenerator(NewGenerator(WithCharacterSet(charSet), WithInitial("5"))), WithCrossBucket())
+
+	key, err := bucket.Between("0|8", "1|3")
+	noError(t, err)
+	equalBucketKey(t, key, "1|2")
+
+	var be *BetweenError
+	if _, err := bucket.Between("1|555", "0|555"); !errors.As(err, &be) || ErrorCode(err) != CodeOrderViolation {
+		t.Fatalf("expected order violation, got %v", err)
+	}
+}
+
+func TestWithSeparatorString(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789abcdefghijklmnopqrstuvwxyz")
+	noError(t, err)
+
+	bucket := NewBucket(WithGenerator(NewGenerator(WithCharacterSet(charSet), WithInitial("555"))), WithSeparatorString("|r|"))
+	noError(t, ValidateBucketSeparator(bucket))
+	if bucket.SeparatorString() != "|r|" || bucket.Separator() != '|' {
+		t.Fatalf("unexpected separator: %s", bucket.SeparatorString())
+	}
+
+	key, err := bucket.InitialIn("r")
+	noError(t, err)
+	equalBucketKey(t, key, "r|r|555")
+
+	key, err = bucket.Next(key)
+	noError(t, err)
+	equalBucketKey(t, key, "r|r|556")
+
+	_, err = bucket.InitialIn("a|r")
+	if err == nil {
+		t.Fatal("expected error for bucket containing the separator")
+	}
+
+	for _, b := range []*Bucket{
+		NewBucket(WithSeparatorString("")),
+		NewBucket(WithSeparatorString("ab")),
+		NewBucket(WithSeparatorString("::"), WithDefaultPrefix("a:")),
+	} {
+		if err := ValidateBucketSeparator(b); err == nil {
+			t.Fatalf("expected error for separator %q", b.SeparatorString())
+		}
+	}
+}
+
+func TestWithInitialFunc(t *testing.T) {
+	bucket := NewBucket(WithInitialFunc(func(bucket string) string {
+		if bucket == "1" {
+			return "1"
+		}
+		return ""
+	}))
+
+	key, err := bucket.Initial()
+	noError(t, err)
+	equalBucketKey(t, key, "0|UUUUUU")
+
+	key, err = bucket.InitialIn("1")
+	noError(t, err)
+	equalBucketKey(t, key, "1|1")
+
+	key, err = bucket.InitialIn("2")
+	noError(t, err)
+	equalBucketKey(t, key, "2|UUUUUU")
+
+	t.Run("prefix", func(t *testing.T) {
+		bucket := NewBucket(WithGenerator(NewGenerator(WithKeyPrefix("rnk_"))), WithInitialFunc(func(string) string {
+			return "1"
+		}))
+		key, err := bucket.InitialIn("1")
+		noError(t, err)
+		equalBucketKey(t, key, "1|rnk_1")
+
+		_, k := bucket.SplitBucketKey(key)
+		next, err := bucket.generator.Between(k, "")
+		noError(t, err)
+		validateKey(t, next, k, "")
+	})
+
+	t.Run("error on invalid character", func(t *testing.T) {
+		bucket := NewBucket(WithInitialFunc(func(string) string {
+			return "a-b"
+		}))
+		if _, err := bucket.InitialIn("1"); ErrorCode(err) != CodeInvalidKey {
+			t.Fatalf("expected CodeInvalidKey, got %v", err)
+		}
+	})
+}
+
 func FuzzGenerator_Between(f *testing.F) {
 	chars := "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
 	charSet, err := NewASCIICharacterSet(chars)
@@ -231,3 +602,82 @@ func FuzzGenerator_Between(f *testing.F) {
 		testRecursive(t, g, prevKey, nextKey, 3)
 	})
 }
+
+func TestWithRandSource(t *testing.T) {
+	newGenerator := func(seed uint64) *Generator {
+		return NewGenerator(WithRandSource(rand.NewPCG(seed, 2)), WithUniqueSuffix(8))
+	}
+	g1, g2, other := newGenerator(1), newGenerator(1), newGenerator(2)
+
+	var key1, key2, otherKey Key
+	for i := 0; i < 10; i++ {
+		var err error
+		key1, err = g1.Between(key1, "")
+		noError(t, err)
+		key2, err = g2.Between(key2, "")
+		noError(t, err)
+		otherKey, err = other.Between(otherKey, "")
+		noError(t, err)
+		if key1 != key2 {
+			t.Fatalf("expected the same key for the same seed, got %q and %q", key1, key2)
+		}
+	}
+	if key1 == otherKey {
+		t.Fatalf("expected different keys for different seeds, got %q", key1)
+	}
+}
+
+func TestBucketKey_Validate(t *testing.T) {
+	b := NewBucket(WithSeparatorString("::"))
+	noError(t, BucketKey("0::abc").Validate(b))
+
+	tests := []struct {
+		key  BucketKey
+		part BucketKeyPart
+	}{
+		{"0|abc", BucketKeySeparator},
+		{"::abc", BucketKeySeparator},
+		{"0::", BucketKeySeparator},
+		{"a-b::abc", BucketKeyLabel},
+		{"0::ab c", BucketKeyRank},
+		{"0::a::b", BucketKeyRank},
+	}
+	for _, tt := range tests {
+		var keyErr *BucketKeyError
+		if err := tt.key.Validate(b); !errors.As(err, &keyErr) || keyErr.Part != tt.part {
+			t.Fatalf("%q: expected invalid %s, got %v", tt.key, tt.part, err)
+		}
+	}
+}
+
+func TestGenerator_InsertAt(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"))
+	keys := []Key{"3", "5", "7"}
+
+	for _, tt := range []struct {
+		index int
+		want  Key
+	}{
+		{0, "2"},
+		{1, "4"},
+		{2, "6"},
+		{3, "8"},
+	} {
+		key, err := g.InsertAt(keys, tt.index)
+		noError(t, err)
+		equalKey(t, key, tt.want)
+	}
+
+	key, err := g.InsertAt(nil, 0)
+	noError(t, err)
+	equalKey(t, key, "5")
+
+	for _, index := range []int{-1, 4} {
+		if _, err := g.InsertAt(keys, index); ErrorCode(err) != CodeInvalidArgument {
+			t.Fatalf("%d: expected invalid argument, got %v", index, err)
+		}
+	}
+}
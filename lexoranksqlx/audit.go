@@ -0,0 +1,53 @@
+package lexoranksqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/morikuni/go-lexorank"
+)
+
+// AuditResult is the result of Audit.
+type AuditResult struct {
+	// IDs are the IDs of the rows ordered by the order column.
+	// Indexes in Report refer to this slice.
+	IDs    []any
+	Report lexorank.AuditReport
+}
+
+// Audit checks the key column of the table against the order of orderColumn, which is the expected order of the rows,
+// and reports invalid, duplicate and out-of-order keys along with a repair plan. See lexorank.Generator.Audit.
+func Audit(ctx context.Context, tx Tx, g *lexorank.Generator, table Table, idColumn, orderColumn string) (AuditResult, error) {
+	query := fmt.Sprintf("SELECT %s, %s FROM %s%s ORDER BY %s",
+		idColumn, table.Column, table.Name, whereClause(table), orderColumn)
+	if r, ok := tx.(interface{ Rebind(string) string }); ok {
+		query = r.Rebind(query)
+	}
+	rows, err := tx.QueryContext(ctx, query, table.Args...)
+	if err != nil {
+		return AuditResult{}, fmt.Errorf("failed to select keys: %w", err)
+	}
+	defer rows.Close()
+
+	var result AuditResult
+	var keys []lexorank.Key
+	for rows.Next() {
+		var id any
+		var key sql.NullString
+		if err := rows.Scan(&id, &key); err != nil {
+			return AuditResult{}, fmt.Errorf("failed to scan key: %w", err)
+		}
+		result.IDs = append(result.IDs, id)
+		keys = append(keys, lexorank.Key(key.String))
+	}
+	if err := rows.Err(); err != nil {
+		return AuditResult{}, fmt.Errorf("failed to select keys: %w", err)
+	}
+
+	result.Report, err = g.Audit(keys)
+	if err != nil {
+		return AuditResult{}, err
+	}
+	return result, nil
+}
@@ -0,0 +1,47 @@
+package lexoranksqlx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"reflect"
+	"testing"
+
+	"github.com/morikuni/go-lexorank"
+)
+
+func TestAudit(t *testing.T) {
+	conn := &fakeConn{rows: [][]driver.Value{
+		{int64(1), "1"},
+		{int64(2), "3"},
+		{int64(3), nil},
+		{int64(4), "5"},
+	}}
+	db := sql.OpenDB(conn)
+	defer db.Close()
+
+	charSet, err := lexorank.NewASCIICharacterSet("0123456789")
+	noError(t, err)
+	g := lexorank.NewGenerator(lexorank.WithCharacterSet(charSet))
+
+	result, err := Audit(context.Background(), db, g, Table{Name: "items", Column: "rank"}, "id", "created_at")
+	noError(t, err)
+
+	want := AuditResult{
+		IDs: []any{int64(1), int64(2), int64(3), int64(4)},
+		Report: lexorank.AuditReport{
+			Issues: []lexorank.AuditIssue{
+				{Index: 2, Key: "", Reason: lexorank.AuditInvalidKey},
+			},
+			Repairs: []lexorank.AuditRepair{
+				{Index: 2, KeyChange: lexorank.KeyChange{Old: "", New: "4"}},
+			},
+		},
+	}
+	if !reflect.DeepEqual(result, want) {
+		t.Fatalf("expected %+v, got %+v", want, result)
+	}
+	if want := "SELECT id, rank FROM items ORDER BY created_at"; conn.queries[0] != want {
+		t.Fatalf("expected %q, got %q", want, conn.queries[0])
+	}
+}
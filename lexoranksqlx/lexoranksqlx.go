@@ -0,0 +1,115 @@
+// Package lexoranksqlx provides helpers to generate keys from rows stored in SQL databases.
+//
+// The helpers accept *sql.Tx as well as *sqlx.Tx. Queries are written with "?" bind variables
+// and rebound by the Rebind method of the transaction when it is available, as sqlx does.
+package lexoranksqlx
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/morikuni/go-lexorank"
+)
+
+// Tx is a transaction to query neighbor keys. Both *sql.Tx and *sqlx.Tx satisfy it.
+type Tx interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// Table describes where keys are stored.
+type Table struct {
+	// Name is the name of the table.
+	Name string
+	// Column is the name of the column storing keys.
+	Column string
+	// Where is an optional condition to select rows of a single list, e.g. "list_id = ?".
+	Where string
+	// Args are the arguments for the bind variables in Where.
+	Args []any
+}
+
+// KeyAt returns a key for a new row to be inserted at index, so that the row becomes the index-th row
+// ordered by the key column. An index at or beyond the number of rows appends the row to the end.
+//
+// The neighbor rows are selected with FOR UPDATE, so KeyAt must be called in the transaction
+// that inserts the new row.
+func KeyAt(ctx context.Context, tx Tx, g *lexorank.Generator, table Table, index int) (lexorank.Key, error) {
+	if index < 0 {
+		return "", fmt.Errorf("index must not be negative: %d", index)
+	}
+	limit, offset := 2, index-1
+	if index == 0 {
+		limit, offset = 1, 0
+	}
+	keys, err := selectKeys(ctx, tx, table, limit, offset)
+	if err != nil {
+		return "", err
+	}
+
+	var prev, next lexorank.Key
+	switch {
+	case index == 0 && len(keys) > 0:
+		next = keys[0]
+	case index > 0 && len(keys) == 1:
+		prev = keys[0]
+	case index > 0 && len(keys) == 2:
+		prev, next = keys[0], keys[1]
+	case index > 0:
+		// The table has fewer rows than index. Append the row after the last one.
+		last, err := selectLastKey(ctx, tx, table)
+		if err != nil {
+			return "", err
+		}
+		prev = last
+	}
+	return g.Between(prev, next)
+}
+
+func selectKeys(ctx context.Context, tx Tx, table Table, limit, offset int) ([]lexorank.Key, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s%s ORDER BY %s LIMIT %d OFFSET %d FOR UPDATE",
+		table.Column, table.Name, whereClause(table), table.Column, limit, offset)
+	return queryKeys(ctx, tx, query, table.Args)
+}
+
+func selectLastKey(ctx context.Context, tx Tx, table Table) (lexorank.Key, error) {
+	query := fmt.Sprintf("SELECT %s FROM %s%s ORDER BY %s DESC LIMIT 1 FOR UPDATE",
+		table.Column, table.Name, whereClause(table), table.Column)
+	keys, err := queryKeys(ctx, tx, query, table.Args)
+	if err != nil || len(keys) == 0 {
+		return "", err
+	}
+	return keys[0], nil
+}
+
+func whereClause(table Table) string {
+	if strings.TrimSpace(table.Where) == "" {
+		return ""
+	}
+	return " WHERE " + table.Where
+}
+
+func queryKeys(ctx context.Context, tx Tx, query string, args []any) ([]lexorank.Key, error) {
+	if r, ok := tx.(interface{ Rebind(string) string }); ok {
+		query = r.Rebind(query)
+	}
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to select neighbor keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []lexorank.Key
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan neighbor key: %w", err)
+		}
+		keys = append(keys, lexorank.Key(key))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to select neighbor keys: %w", err)
+	}
+	return keys, nil
+}
@@ -0,0 +1,154 @@
+package lexoranksqlx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/morikuni/go-lexorank"
+)
+
+func TestKeyAt(t *testing.T) {
+	charSet, err := lexorank.NewASCIICharacterSet("0123456789")
+	noError(t, err)
+	g := lexorank.NewGenerator(lexorank.WithCharacterSet(charSet), lexorank.WithInitial("5"))
+
+	for _, tt := range []struct {
+		keys  []string
+		index int
+		want  lexorank.Key
+	}{
+		{nil, 0, "5"},
+		{nil, 3, "5"},
+		{[]string{"3", "5", "7"}, 0, "2"},
+		{[]string{"3", "5", "7"}, 1, "4"},
+		{[]string{"3", "5", "7"}, 2, "6"},
+		{[]string{"3", "5", "7"}, 3, "8"},
+		{[]string{"3", "5", "7"}, 10, "8"},
+	} {
+		t.Run(strings.Join(tt.keys, ",")+"_"+strconv.Itoa(tt.index), func(t *testing.T) {
+			conn := &fakeConn{keys: tt.keys}
+			db := sql.OpenDB(conn)
+			defer db.Close()
+
+			tx, err := db.Begin()
+			noError(t, err)
+			defer tx.Rollback()
+
+			key, err := KeyAt(context.Background(), tx, g, Table{Name: "items", Column: "rank"}, tt.index)
+			noError(t, err)
+			if key != tt.want {
+				t.Fatalf("expected %s, got %s", tt.want, key)
+			}
+		})
+	}
+}
+
+func TestKeyAt_Rebind(t *testing.T) {
+	conn := &fakeConn{keys: []string{"3", "5"}}
+	db := sql.OpenDB(conn)
+	defer db.Close()
+
+	tx, err := db.Begin()
+	noError(t, err)
+	defer tx.Rollback()
+
+	table := Table{Name: "items", Column: "rank", Where: "list_id = ?", Args: []any{1}}
+	_, err = KeyAt(context.Background(), rebindTx{tx}, lexorank.NewGenerator(), table, 1)
+	noError(t, err)
+
+	want := "SELECT rank FROM items WHERE list_id = $1 ORDER BY rank LIMIT 2 OFFSET 0 FOR UPDATE"
+	if conn.queries[0] != want {
+		t.Fatalf("expected %q, got %q", want, conn.queries[0])
+	}
+}
+
+func noError(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+}
+
+type rebindTx struct {
+	*sql.Tx
+}
+
+func (rebindTx) Rebind(query string) string {
+	n := 0
+	return regexp.MustCompile(`\?`).ReplaceAllStringFunc(query, func(string) string {
+		n++
+		return "$" + strconv.Itoa(n)
+	})
+}
+
+// fakeConn is a driver connection that answers the queries of this package from sorted keys.
+// If rows is set, every query returns rows as is. If answer is set, it answers every query.
+type fakeConn struct {
+	keys    []string
+	rows    [][]driver.Value
+	answer  func(query string, args []driver.NamedValue) [][]driver.Value
+	queries []string
+}
+
+var limitOffsetPattern = regexp.MustCompile(`LIMIT (\d+)(?: OFFSET (\d+))?`)
+
+func (c *fakeConn) Connect(context.Context) (driver.Conn, error) { return c, nil }
+func (c *fakeConn) Driver() driver.Driver                        { return nil }
+func (c *fakeConn) Prepare(string) (driver.Stmt, error)          { return nil, driver.ErrSkip }
+func (c *fakeConn) Close() error                                 { return nil }
+func (c *fakeConn) Begin() (driver.Tx, error)                    { return c, nil }
+func (c *fakeConn) Commit() error                                { return nil }
+func (c *fakeConn) Rollback() error                              { return nil }
+
+func (c *fakeConn) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	c.queries = append(c.queries, query)
+	if c.answer != nil {
+		return &fakeRows{rows: c.answer(query, args)}, nil
+	}
+	if c.rows != nil {
+		return &fakeRows{rows: c.rows}, nil
+	}
+	keys := slices.Clone(c.keys)
+	if strings.Contains(query, " DESC ") {
+		slices.Reverse(keys)
+	}
+	m := limitOffsetPattern.FindStringSubmatch(query)
+	limit, _ := strconv.Atoi(m[1])
+	offset, _ := strconv.Atoi(m[2])
+	keys = keys[min(offset, len(keys)):]
+	keys = keys[:min(limit, len(keys))]
+	rows := make([][]driver.Value, len(keys))
+	for i, key := range keys {
+		rows[i] = []driver.Value{key}
+	}
+	return &fakeRows{rows: rows}, nil
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return []string{"rank"}
+	}
+	return make([]string, len(r.rows[0]))
+}
+
+func (r *fakeRows) Close() error { return nil }
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+	return nil
+}
@@ -0,0 +1,89 @@
+package lexoranksqlx
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+
+	"github.com/morikuni/go-lexorank"
+)
+
+// ProbePostgresCollation checks that the collation of the column orders keys of the character set the same way as Go,
+// by comparing adjacent characters of the set and samples random keys in Postgres.
+// Call it at startup to fail fast when the column does not use a byte-order collation such as "C".
+func ProbePostgresCollation(ctx context.Context, tx Tx, set lexorank.CharacterSet, table Table, samples int) error {
+	collation, err := columnCollation(ctx, tx, table)
+	if err != nil {
+		return err
+	}
+
+	var pairs [][2]lexorank.Key
+	for r, ok := set.Min(), true; ok; {
+		next, hasNext := set.Next(r)
+		if hasNext {
+			pairs = append(pairs, [2]lexorank.Key{lexorank.Key(r), lexorank.Key(next)})
+		}
+		r, ok = next, hasNext
+	}
+	rnd := rand.New(rand.NewPCG(0, 0))
+	for range samples {
+		prev, next, err := lexorank.RandomKeyPair(rnd, set, 8)
+		if err != nil {
+			return err
+		}
+		key, err := lexorank.BetweenIn(set, prev, next)
+		if err != nil {
+			return err
+		}
+		pairs = append(pairs, [2]lexorank.Key{prev, key}, [2]lexorank.Key{key, next})
+	}
+
+	query := "SELECT $1::text < $2::text"
+	if collation != "" {
+		query += ` COLLATE "` + strings.ReplaceAll(collation, `"`, `""`) + `"`
+	}
+	for _, pair := range pairs {
+		rows, err := tx.QueryContext(ctx, query, string(pair[0]), string(pair[1]))
+		if err != nil {
+			return fmt.Errorf("failed to probe collation: %w", err)
+		}
+		var less bool
+		if rows.Next() {
+			err = rows.Scan(&less)
+		}
+		if err == nil {
+			err = rows.Err()
+		}
+		rows.Close()
+		if err != nil {
+			return fmt.Errorf("failed to probe collation: %w", err)
+		}
+		if !less {
+			return fmt.Errorf("collation %q of %s.%s does not sort %q before %q", collation, table.Name, table.Column, pair[0], pair[1])
+		}
+	}
+	return nil
+}
+
+// columnCollation returns the collation of the column, or empty for the default collation of the database.
+func columnCollation(ctx context.Context, tx Tx, table Table) (string, error) {
+	rows, err := tx.QueryContext(ctx,
+		"SELECT COALESCE(collation_name, '') FROM information_schema.columns WHERE table_name = $1 AND column_name = $2",
+		table.Name, table.Column)
+	if err != nil {
+		return "", fmt.Errorf("failed to select collation: %w", err)
+	}
+	defer rows.Close()
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", fmt.Errorf("failed to select collation: %w", err)
+		}
+		return "", fmt.Errorf("column %s.%s is not found", table.Name, table.Column)
+	}
+	var collation string
+	if err := rows.Scan(&collation); err != nil {
+		return "", fmt.Errorf("failed to select collation: %w", err)
+	}
+	return collation, nil
+}
@@ -0,0 +1,36 @@
+package lexoranksqlx
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"strings"
+	"testing"
+
+	"github.com/morikuni/go-lexorank"
+)
+
+func TestProbePostgresCollation(t *testing.T) {
+	probe := func(collation string, less func(a, b string) bool) (*fakeConn, error) {
+		conn := &fakeConn{answer: func(query string, args []driver.NamedValue) [][]driver.Value {
+			if strings.Contains(query, "information_schema") {
+				return [][]driver.Value{{collation}}
+			}
+			return [][]driver.Value{{less(args[0].Value.(string), args[1].Value.(string))}}
+		}}
+		db := sql.OpenDB(conn)
+		defer db.Close()
+		return conn, ProbePostgresCollation(context.Background(), db, lexorank.DefaultCharacterSet, Table{Name: "items", Column: "rank"}, 10)
+	}
+
+	conn, err := probe("C", func(a, b string) bool { return a < b })
+	noError(t, err)
+	if want := `SELECT $1::text < $2::text COLLATE "C"`; conn.queries[1] != want {
+		t.Fatalf("expected %q, got %q", want, conn.queries[1])
+	}
+
+	_, err = probe("en_US", func(a, b string) bool { return strings.ToLower(a) < strings.ToLower(b) })
+	if err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+}
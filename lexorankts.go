@@ -0,0 +1,172 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// Base36CharacterSet is the character set of the numeral system of Jira's LexoRank and the lexorank-ts library.
+var Base36CharacterSet CharacterSet = newCharacterSet("0123456789abcdefghijklmnopqrstuvwxyz")
+
+var (
+	tsRankDigits     = newKeyDigits(Base36CharacterSet)
+	tsRankMin        = newDecimal(tsRankDigits, big.NewInt(0), 0)
+	tsRankMax        = newDecimal(tsRankDigits, tsRankDigits.max(6), 0)
+	tsRankInitialMin = newDecimal(tsRankDigits, big.NewInt(36*36*36*36*36), 0)    // "100000"
+	tsRankInitialMax = newDecimal(tsRankDigits, big.NewInt(34*36*36*36*36*36), 0) // "y00000"
+	tsRankStep       = newDecimal(tsRankDigits, big.NewInt(8), 0)
+	tsRankHalf       = newDecimal(tsRankDigits, big.NewInt(18), 1) // "0:i"
+)
+
+// TSRank is a rank in the format "0|hzzzzz:" computed identically to the LexoRank class of the lexorank-ts
+// library, so that a TypeScript frontend can compute the same rank that a Go backend stores.
+type TSRank struct {
+	bucket  string
+	decimal Decimal
+}
+
+// ParseTSRank parses a rank in the format of lexorank-ts, whose bucket is "0", "1" or "2".
+func ParseTSRank(s string) (TSRank, error) {
+	bucket, value, ok := strings.Cut(s, "|")
+	if !ok || (bucket != "0" && bucket != "1" && bucket != "2") {
+		return TSRank{}, withCode(CodeInvalidKey, fmt.Errorf("invalid rank %q: bucket must be 0, 1 or 2", s))
+	}
+	decimal, err := ParseDecimal(Base36CharacterSet, value)
+	if err != nil {
+		return TSRank{}, err
+	}
+	if decimal.Sign() < 0 || decimal.Cmp(tsRankMax) > 0 {
+		return TSRank{}, withCode(CodeInvalidKey, fmt.Errorf("invalid rank %q: out of range", s))
+	}
+	return TSRank{bucket, decimal}, nil
+}
+
+// TSRankMin returns the min rank "0|000000:", which is LexoRank.min() of lexorank-ts.
+func TSRankMin() TSRank {
+	return TSRank{"0", tsRankMin}
+}
+
+// TSRankMax returns the max rank "0|zzzzzz:", which is LexoRank.max() of lexorank-ts.
+func TSRankMax() TSRank {
+	return TSRank{"0", tsRankMax}
+}
+
+// TSRankMiddle returns the middle rank "0|hzzzzz:", which is LexoRank.middle() of lexorank-ts.
+func TSRankMiddle() TSRank {
+	return TSRank{"0", tsRankBetween(tsRankMin, tsRankMax)}
+}
+
+// Bucket returns the bucket of the rank.
+func (r TSRank) Bucket() string {
+	return r.bucket
+}
+
+// GenNext returns the rank after r, which is LexoRank.genNext() of lexorank-ts.
+func (r TSRank) GenNext() TSRank {
+	if r.decimal.Cmp(tsRankMin) == 0 {
+		return TSRank{r.bucket, tsRankInitialMin}
+	}
+	next := r.decimal.SetScale(0, true).Add(tsRankStep)
+	if next.Cmp(tsRankMax) >= 0 {
+		next = tsRankBetween(r.decimal, tsRankMax)
+	}
+	return TSRank{r.bucket, next}
+}
+
+// GenPrev returns the rank before r, which is LexoRank.genPrev() of lexorank-ts.
+func (r TSRank) GenPrev() TSRank {
+	if r.decimal.Cmp(tsRankMax) == 0 {
+		return TSRank{r.bucket, tsRankInitialMax}
+	}
+	prev := r.decimal.SetScale(0, false).Subtract(tsRankStep)
+	if prev.Cmp(tsRankMin) <= 0 {
+		prev = tsRankBetween(tsRankMin, r.decimal)
+	}
+	return TSRank{r.bucket, prev}
+}
+
+// Between returns the rank between r and other, which is LexoRank.between() of lexorank-ts.
+// The ranks must be in the same bucket and must not be equal, but can be in either order.
+func (r TSRank) Between(other TSRank) (TSRank, error) {
+	if r.bucket != other.bucket {
+		return TSRank{}, fmt.Errorf("%w: %q != %q", ErrBucketMismatch, r.bucket, other.bucket)
+	}
+	switch r.decimal.Cmp(other.decimal) {
+	case 0:
+		return TSRank{}, withCode(CodeOrderViolation, errors.New("ranks must not be equal"))
+	case 1:
+		return TSRank{r.bucket, tsRankBetween(other.decimal, r.decimal)}, nil
+	default:
+		return TSRank{r.bucket, tsRankBetween(r.decimal, other.decimal)}, nil
+	}
+}
+
+// String returns the rank with the integer part padded to 6 digits, e.g. "0|hzzzzz:".
+func (r TSRank) String() string {
+	s := r.decimal.String()
+	integer, fraction, _ := strings.Cut(s, string(DecimalRadixPoint))
+	return r.bucket + "|" + strings.Repeat("0", max(6-len(integer), 0)) + integer + string(DecimalRadixPoint) + fraction
+}
+
+// tsRankBetween is LexoRank.between(LexoDecimal, LexoDecimal) of lexorank-ts.
+func tsRankBetween(oLeft, oRight Decimal) Decimal {
+	left, right := oLeft, oRight
+	if oLeft.scale < oRight.scale {
+		nLeft := oRight.SetScale(oLeft.scale, false)
+		if oLeft.Cmp(nLeft) >= 0 {
+			return tsRankMid(oLeft, oRight)
+		}
+		right = nLeft
+	}
+	if oLeft.scale > right.scale {
+		nLeft := oLeft.SetScale(right.scale, true)
+		if nLeft.Cmp(right) >= 0 {
+			return tsRankMid(oLeft, oRight)
+		}
+		left = nLeft
+	}
+	for scale := left.scale; scale > 0; scale-- {
+		nLeft := left.SetScale(scale-1, true)
+		nRight := right.SetScale(scale-1, false)
+		cmp := nLeft.Cmp(nRight)
+		if cmp == 0 {
+			return tsRankCheckMid(oLeft, oRight, nLeft)
+		}
+		if cmp > 0 {
+			break
+		}
+		left, right = nLeft, nRight
+	}
+	mid := tsRankCheckMid(oLeft, oRight, tsRankMid(left, right))
+	for scale := mid.scale; scale > 0; scale-- {
+		nMid := mid.SetScale(scale-1, false)
+		if oLeft.Cmp(nMid) >= 0 || nMid.Cmp(oRight) >= 0 {
+			break
+		}
+		mid = nMid
+	}
+	return mid
+}
+
+func tsRankCheckMid(lbound, rbound, mid Decimal) Decimal {
+	if lbound.Cmp(mid) >= 0 || mid.Cmp(rbound) >= 0 {
+		return tsRankMid(lbound, rbound)
+	}
+	return mid
+}
+
+func tsRankMid(left, right Decimal) Decimal {
+	mid := left.Add(right).Multiply(tsRankHalf)
+	scale := max(left.scale, right.scale)
+	if mid.scale > scale {
+		if roundDown := mid.SetScale(scale, false); roundDown.Cmp(left) > 0 {
+			return roundDown
+		}
+		if roundUp := mid.SetScale(scale, true); roundUp.Cmp(right) < 0 {
+			return roundUp
+		}
+	}
+	return mid
+}
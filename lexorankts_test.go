@@ -0,0 +1,58 @@
+package lexorank
+
+import "testing"
+
+// The expected ranks are those of lexorank-ts for the same operations.
+func TestTSRank(t *testing.T) {
+	parse := func(s string) TSRank {
+		t.Helper()
+		r, err := ParseTSRank(s)
+		noError(t, err)
+		return r
+	}
+	equal := func(r TSRank, want string) {
+		t.Helper()
+		if r.String() != want {
+			t.Fatalf("expected %q, got %q", want, r.String())
+		}
+	}
+
+	equal(TSRankMin(), "0|000000:")
+	equal(TSRankMax(), "0|zzzzzz:")
+	equal(TSRankMiddle(), "0|hzzzzz:")
+	equal(TSRankMiddle().GenNext(), "0|i00007:")
+	equal(TSRankMiddle().GenPrev(), "0|hzzzzr:")
+	equal(TSRankMin().GenNext(), "0|100000:")
+	equal(TSRankMax().GenPrev(), "0|y00000:")
+	equal(parse("0|0i0000:").GenNext(), "0|0i0008:")
+	equal(parse("1|zzzzzz:").GenPrev(), "1|y00000:")
+	equal(parse("0|zzzzzx:").GenNext(), "0|zzzzzy:")
+	equal(parse("0|000001:").GenPrev(), "0|000000:i")
+
+	for _, tt := range []struct {
+		a, b, want string
+	}{
+		{"0|hzzzzz:", "0|i00007:", "0|i00003:"},
+		{"0|i00007:", "0|hzzzzz:", "0|i00003:"},
+		{"0|hzzzzz:", "0|i00000:", "0|hzzzzz:i"},
+		{"0|hzzzzz:i", "0|i00000:", "0|hzzzzz:r"},
+		{"0|hzzzzz:", "0|hzzzzz:i", "0|hzzzzz:9"},
+		{"0|000000:", "0|zzzzzz:", "0|hzzzzz:"},
+	} {
+		r, err := parse(tt.a).Between(parse(tt.b))
+		noError(t, err)
+		equal(r, tt.want)
+	}
+
+	if _, err := parse("0|hzzzzz:").Between(parse("1|hzzzzz:")); err == nil {
+		t.Fatal("expected error for different buckets")
+	}
+	if _, err := parse("0|hzzzzz:").Between(parse("0|hzzzzz:")); err == nil {
+		t.Fatal("expected error for equal ranks")
+	}
+	for _, s := range []string{"hzzzzz:", "3|hzzzzz:", "0|1000000:", "0|HZZZZZ:"} {
+		if _, err := ParseTSRank(s); err == nil {
+			t.Fatalf("%q: expected error", s)
+		}
+	}
+}
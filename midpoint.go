@@ -0,0 +1,129 @@
+package lexorank
+
+import (
+	"math/rand/v2"
+	"sync/atomic"
+)
+
+// MidpointStrategy selects the character that Generator places between two characters.
+type MidpointStrategy interface {
+	// Midpoint returns a character from a (inclusive) to b (exclusive) in the character set,
+	// treating the set as a circular sequence like CharacterSet.Mid. If a equals b, it returns a.
+	// r is the source of randomness configured by WithRandSource.
+	Midpoint(set CharacterSet, a, b rune, r *rand.Rand) rune
+}
+
+var (
+	// CenteredMidpoint selects the character at the center, which is CharacterSet.Mid.
+	// When there are two characters at the center, it selects the one closer to a.
+	// It is the default strategy.
+	CenteredMidpoint MidpointStrategy = centeredMidpoint{}
+	// CeilMidpoint selects the character at the center like CenteredMidpoint,
+	// but selects the one closer to b when there are two characters at the center.
+	CeilMidpoint MidpointStrategy = ceilMidpoint{}
+	// BiasedLeftMidpoint selects the character at a quarter from a, leaving more room after generated keys.
+	// Like the other strategies, it selects a character strictly between a and b whenever there is one.
+	// It suits lists where items are mostly appended after the previous insertion.
+	BiasedLeftMidpoint MidpointStrategy = biasedMidpoint{1, 4}
+	// BiasedRightMidpoint selects the character at three quarters from a, leaving more room before generated keys.
+	// It suits lists where items are mostly prepended before the previous insertion.
+	BiasedRightMidpoint MidpointStrategy = biasedMidpoint{3, 4}
+	// RandomMidpoint selects a random character strictly between a and b when there is one,
+	// so that concurrent writers are unlikely to generate the same key.
+	RandomMidpoint MidpointStrategy = randomMidpoint{}
+)
+
+type centeredMidpoint struct{}
+
+func (centeredMidpoint) Midpoint(set CharacterSet, a, b rune, _ *rand.Rand) rune {
+	return set.Mid(a, b)
+}
+
+type ceilMidpoint struct{}
+
+func (ceilMidpoint) Midpoint(set CharacterSet, a, b rune, _ *rand.Rand) rune {
+	distance := circularDistance(set, a, b)
+	if distance < 2 {
+		return a
+	}
+	return circularAdvance(set, a, (distance+1)/2)
+}
+
+// NewAlternatingMidpoint returns a MidpointStrategy that selects the character at the center,
+// alternating between CenteredMidpoint and CeilMidpoint on each call, so that repeated splits of even gaps
+// are not biased toward either side. A Generator alternates on each generated key rather than on each character.
+// The returned strategy is safe for concurrent use.
+func NewAlternatingMidpoint() MidpointStrategy {
+	return &alternatingMidpoint{}
+}
+
+type alternatingMidpoint struct {
+	calls atomic.Uint64
+}
+
+func (m *alternatingMidpoint) Midpoint(set CharacterSet, a, b rune, r *rand.Rand) rune {
+	return m.next().Midpoint(set, a, b, r)
+}
+
+// next returns the strategy to use for the next call.
+func (m *alternatingMidpoint) next() MidpointStrategy {
+	if m.calls.Add(1)%2 == 0 {
+		return CeilMidpoint
+	}
+	return CenteredMidpoint
+}
+
+type biasedMidpoint struct {
+	numerator   int
+	denominator int
+}
+
+func (m biasedMidpoint) Midpoint(set CharacterSet, a, b rune, _ *rand.Rand) rune {
+	distance := circularDistance(set, a, b)
+	if distance < 2 {
+		return a
+	}
+	// Stay strictly between a and b, so that narrow gaps do not lengthen the key.
+	return circularAdvance(set, a, min(max(distance*m.numerator/m.denominator, 1), distance-1))
+}
+
+type randomMidpoint struct{}
+
+func (randomMidpoint) Midpoint(set CharacterSet, a, b rune, r *rand.Rand) rune {
+	distance := circularDistance(set, a, b)
+	if distance < 2 {
+		return a
+	}
+	return circularAdvance(set, a, 1+r.IntN(distance-1))
+}
+
+// circularDistance returns the number of steps from a to b in the character set, wrapping around the end.
+func circularDistance(set CharacterSet, a, b rune) int {
+	n := 0
+	for r := a; r != b; r = circularNext(set, r) {
+		n++
+	}
+	return n
+}
+
+// circularAdvance returns the character n steps after r in the character set, wrapping around the end.
+func circularAdvance(set CharacterSet, r rune, n int) rune {
+	for ; n > 0; n-- {
+		r = circularNext(set, r)
+	}
+	return r
+}
+
+func circularNext(set CharacterSet, r rune) rune {
+	if next, ok := set.Next(r); ok {
+		return next
+	}
+	return set.Min()
+}
+
+// WithMidpointStrategy returns a GeneratorOption that sets the strategy to select characters between two characters.
+func WithMidpointStrategy(s MidpointStrategy) GeneratorOption {
+	return func(g *Generator) {
+		g.midpoint = s
+	}
+}
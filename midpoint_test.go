@@ -0,0 +1,109 @@
+package lexorank
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestMidpointStrategy(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	for _, tt := range []struct {
+		strategy MidpointStrategy
+		a        rune
+		b        rune
+		want     rune
+	}{
+		{CenteredMidpoint, '1', '9', '5'},
+		{CenteredMidpoint, '8', '2', '0'},
+		{CenteredMidpoint, '1', '4', '2'},
+		{CeilMidpoint, '1', '4', '3'},
+		{CeilMidpoint, '1', '5', '3'},
+		{CeilMidpoint, '8', '1', '0'},
+		{CeilMidpoint, '1', '2', '1'},
+		{BiasedLeftMidpoint, '1', '9', '3'},
+		{BiasedLeftMidpoint, '8', '2', '9'},
+		{BiasedLeftMidpoint, '1', '2', '1'},
+		{BiasedLeftMidpoint, '1', '3', '2'},
+		{BiasedLeftMidpoint, '1', '4', '2'},
+		{BiasedRightMidpoint, '1', '9', '7'},
+		{BiasedRightMidpoint, '8', '2', '1'},
+		{BiasedRightMidpoint, '1', '2', '1'},
+		{BiasedRightMidpoint, '1', '3', '2'},
+		{RandomMidpoint, '1', '2', '1'},
+		{RandomMidpoint, '1', '3', '2'},
+	} {
+		t.Run(fmt.Sprintf("%T_%c_%c", tt.strategy, tt.a, tt.b), func(t *testing.T) {
+			got := tt.strategy.Midpoint(charSet, tt.a, tt.b, rand.New(rand.NewPCG(1, 2)))
+			if got != tt.want {
+				t.Fatalf("expected %c, got %c", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestNewAlternatingMidpoint(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	m := NewAlternatingMidpoint()
+	var got []rune
+	for i := 0; i < 4; i++ {
+		got = append(got, m.Midpoint(charSet, '1', '4', nil))
+	}
+	if string(got) != "2323" {
+		t.Fatalf("expected 2323, got %s", string(got))
+	}
+
+	t.Run("through Generator", func(t *testing.T) {
+		g := NewGenerator(WithCharacterSet(charSet), WithMidpointStrategy(NewAlternatingMidpoint()))
+		var got []Key
+		for i := 0; i < 4; i++ {
+			key, err := g.Between("1", "4")
+			noError(t, err)
+			got = append(got, key)
+		}
+		if want := []Key{"2", "3", "2", "3"}; !slices.Equal(got, want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	})
+}
+
+func TestWithMidpointStrategy(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	for _, strategy := range []MidpointStrategy{CenteredMidpoint, CeilMidpoint, NewAlternatingMidpoint(), BiasedLeftMidpoint, BiasedRightMidpoint, RandomMidpoint} {
+		t.Run(fmt.Sprintf("%T", strategy), func(t *testing.T) {
+			g := NewGenerator(WithCharacterSet(charSet), WithMidpointStrategy(strategy), WithRandSource(rand.NewPCG(1, 2)))
+			testRecursive(t, g, "", "", 12)
+			testRecursive(t, g, "0998", "1", 8)
+			testRecursive(t, g, "5699", "573", 8)
+		})
+	}
+
+	t.Run("shortest key", func(t *testing.T) {
+		for _, strategy := range []MidpointStrategy{CenteredMidpoint, CeilMidpoint, BiasedLeftMidpoint, BiasedRightMidpoint, RandomMidpoint} {
+			g := NewGenerator(WithCharacterSet(charSet), WithMidpointStrategy(strategy))
+			// A gap of two or more characters is split without lengthening the keys.
+			for _, keys := range [][2]Key{{"1", "3"}, {"10", "30"}, {"1", "4"}} {
+				key, err := g.Between(keys[0], keys[1])
+				noError(t, err)
+				validateKey(t, key, keys[0], keys[1])
+				if len(key) != len(keys[0]) {
+					t.Fatalf("%T: unexpected key between %q and %q: %q", strategy, keys[0], keys[1], key)
+				}
+			}
+		}
+	})
+
+	t.Run("biased left", func(t *testing.T) {
+		g := NewGenerator(WithCharacterSet(charSet), WithMidpointStrategy(BiasedLeftMidpoint))
+		key, err := g.Between("1", "9")
+		noError(t, err)
+		equalKey(t, key, "3")
+	})
+}
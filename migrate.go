@@ -0,0 +1,126 @@
+package lexorank
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// PositionsToKeys returns keys of the Generator preserving the order of the positions, such as the values of
+// a float or integer ordering column to be migrated to keys. keys[i] is the key for positions[i].
+// The keys are the shortest evenly spaced keys, and equal positions get keys in the order of their indices.
+func PositionsToKeys[T cmp.Ordered](g *Generator, positions []T) ([]Key, error) {
+	if len(positions) == 0 {
+		return nil, nil
+	}
+	order := make([]int, len(positions))
+	for i := range order {
+		order[i] = i
+	}
+	slices.SortStableFunc(order, func(a, b int) int {
+		return cmp.Compare(positions[a], positions[b])
+	})
+	sorted, err := spreadWithLength(newKeyDigits(g.characterSet), g.pinnedTop, g.pinnedBottom, len(positions))
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]Key, len(positions))
+	for i, index := range order {
+		if keys[index], err = g.finishKey(sorted[i]); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// AssignRanks returns evenly spaced keys of the Generator for items sorted in the order to be kept, such as the
+// rows of an existing dataset adopting keys. keys[i] is the key for items[i]. The keys are the same as those of
+// AssignRanksInChunks. Millions of keys are generated concurrently on multiple CPUs.
+func AssignRanks[T any](g *Generator, items []T) ([]Key, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	keys, err := spreadWithLength(newKeyDigits(g.characterSet), g.pinnedTop, g.pinnedBottom, len(items))
+	if err != nil {
+		return nil, err
+	}
+	for i, key := range keys {
+		if keys[i], err = g.finishKey(key); err != nil {
+			return nil, err
+		}
+	}
+	return keys, nil
+}
+
+// AssignRanksInChunks generates evenly spaced keys of the Generator for sorted items like AssignRanks, and calls f
+// with each chunk of at most size items and their keys in order, so that only one chunk of keys is in memory.
+// The keys slice is reused between calls. If f returns an error, it stops and returns the error.
+func AssignRanksInChunks[T any](g *Generator, items []T, size int, f func(items []T, keys []Key) error) error {
+	if len(items) == 0 {
+		return nil
+	}
+	if size <= 0 {
+		return withCode(CodeInvalidArgument, fmt.Errorf("chunk size must be positive: %d", size))
+	}
+	keyAt, err := spreadFunc(newKeyDigits(g.characterSet), g.pinnedTop, g.pinnedBottom, len(items))
+	if err != nil {
+		return err
+	}
+	keys := make([]Key, 0, min(size, len(items)))
+	for start := 0; start < len(items); start += size {
+		chunk := items[start:min(start+size, len(items))]
+		keys = keys[:0]
+		for i := range chunk {
+			key, err := g.finishKey(keyAt(start + i))
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+		if err := f(chunk, keys); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InitialSeq returns a sequence of n evenly spaced keys of the Generator in ascending order, which are the same
+// as the keys of AssignRanks for n items. The keys are generated lazily, so tens of millions of rows can be
+// seeded without holding all keys in memory. The sequence is empty if the keys cannot be generated, e.g. if they
+// cannot be signed as configured by WithHMAC.
+func (g *Generator) InitialSeq(n int) iter.Seq[Key] {
+	return func(yield func(Key) bool) {
+		if n <= 0 {
+			return
+		}
+		keyAt, err := spreadFunc(newKeyDigits(g.characterSet), g.pinnedTop, g.pinnedBottom, n)
+		if err != nil {
+			return
+		}
+		for i := range n {
+			key, err := g.finishKey(keyAt(i))
+			if err != nil || !yield(key) {
+				return
+			}
+		}
+	}
+}
+
+// DualRank is a rank read while migrating from positions to keys. Key is empty if the row is not migrated yet.
+type DualRank[T cmp.Ordered] struct {
+	Position T
+	Key      Key
+}
+
+// SortDualRanks sorts the ranks by their keys if all of them are migrated, or by their positions otherwise.
+// Rows inserted during the migration should have both of a position and a key to be ordered in either way.
+func SortDualRanks[T cmp.Ordered](ranks []DualRank[T]) {
+	migrated := !slices.ContainsFunc(ranks, func(r DualRank[T]) bool { return r.Key == "" })
+	slices.SortStableFunc(ranks, func(a, b DualRank[T]) int {
+		if migrated {
+			return cmp.Compare(a.Key, b.Key)
+		}
+		return cmp.Compare(a.Position, b.Position)
+	})
+}
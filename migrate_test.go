@@ -0,0 +1,138 @@
+package lexorank
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestPositionsToKeys(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	keys, err := PositionsToKeys(g, []float64{2.5, -1, 10, 2.5})
+	noError(t, err)
+	if !slices.Equal(keys, []Key{"4", "2", "8", "6"}) {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+
+	keys, err = PositionsToKeys(g, []int{3, 1, 2, 5, 4, 9, 8, 7, 6, 0})
+	noError(t, err)
+	if !slices.Equal(keys, []Key{"37", "19", "28", "55", "46", "91", "82", "73", "64", "10"}) {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+}
+
+func TestSortDualRanks(t *testing.T) {
+	ranks := []DualRank[float64]{{2, "1"}, {1, ""}, {3, "0"}}
+	SortDualRanks(ranks)
+	if !slices.Equal(ranks, []DualRank[float64]{{1, ""}, {2, "1"}, {3, "0"}}) {
+		t.Fatalf("expected ranks sorted by positions, got %v", ranks)
+	}
+
+	ranks[0].Key = "2"
+	SortDualRanks(ranks)
+	if !slices.Equal(ranks, []DualRank[float64]{{3, "0"}, {2, "1"}, {1, "2"}}) {
+		t.Fatalf("expected ranks sorted by keys, got %v", ranks)
+	}
+}
+
+func TestAssignRanks(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	keys, err := AssignRanks(g, []string{"a", "b", "c"})
+	noError(t, err)
+	if !slices.Equal(keys, []Key{"3", "5", "7"}) {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+
+	keys, err = AssignRanks(g, []int(nil))
+	noError(t, err)
+	if len(keys) != 0 {
+		t.Fatalf("unexpected keys: %v", keys)
+	}
+}
+
+func TestAssignRanksInChunks(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+	items := make([]int, 25)
+	want, err := AssignRanks(g, items)
+	noError(t, err)
+
+	var got []Key
+	var sizes []int
+	noError(t, AssignRanksInChunks(g, items, 10, func(items []int, keys []Key) error {
+		if len(items) != len(keys) {
+			t.Fatalf("expected %d keys, got %d", len(items), len(keys))
+		}
+		sizes = append(sizes, len(keys))
+		got = append(got, keys...)
+		return nil
+	}))
+	if !slices.Equal(got, want) || !slices.Equal(sizes, []int{10, 10, 5}) {
+		t.Fatalf("unexpected keys: %v %v", got, sizes)
+	}
+	if !slices.IsSorted(got) {
+		t.Fatalf("keys are not sorted: %v", got)
+	}
+
+	if err := AssignRanksInChunks(g, items, 0, func([]int, []Key) error { return nil }); err == nil {
+		t.Fatal("expected error for non-positive chunk size")
+	}
+}
+
+func TestAssignRanks_Parallel(t *testing.T) {
+	g := NewGenerator()
+	items := make([]struct{}, 4*parallelThreshold+1)
+
+	keys, err := AssignRanks(g, items)
+	noError(t, err)
+
+	i := 0
+	noError(t, AssignRanksInChunks(g, items, 1000, func(_ []struct{}, chunk []Key) error {
+		for _, key := range chunk {
+			if keys[i] != key {
+				t.Fatalf("%d: expected %q, got %q", i, key, keys[i])
+			}
+			i++
+		}
+		return nil
+	}))
+	if !slices.IsSorted(keys) {
+		t.Fatal("keys are not sorted")
+	}
+}
+
+func TestGenerator_InitialSeq(t *testing.T) {
+	g := NewGenerator()
+	want, err := AssignRanks(g, make([]int, 1000))
+	noError(t, err)
+
+	if got := slices.Collect(g.InitialSeq(1000)); !slices.Equal(got, want) {
+		t.Fatalf("unexpected keys: %v", got)
+	}
+	for key := range g.InitialSeq(1000) {
+		equalKey(t, key, want[0])
+		break
+	}
+	if got := slices.Collect(g.InitialSeq(0)); len(got) != 0 {
+		t.Fatalf("unexpected keys: %v", got)
+	}
+}
+
+func BenchmarkAssignRanks(b *testing.B) {
+	g := NewGenerator()
+	items := make([]struct{}, 1_000_000)
+	for b.Loop() {
+		if _, err := AssignRanks(g, items); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
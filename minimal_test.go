@@ -0,0 +1,33 @@
+package lexorank
+
+import (
+	"go/build"
+	"slices"
+	"testing"
+)
+
+func TestMinimalBuild(t *testing.T) {
+	// The packages pulled in only by integrations, which must be excluded by the lexorank_minimal build tag.
+	forbidden := []string{
+		"database/sql/driver",
+		"embed",
+		"encoding/csv",
+		"encoding/json",
+		"encoding/xml",
+		"expvar",
+		"net/http",
+		"regexp",
+	}
+
+	for _, tag := range []string{"lexorank_minimal", "tinygo"} {
+		ctxt := build.Default
+		ctxt.BuildTags = []string{tag}
+		pkg, err := ctxt.ImportDir(".", 0)
+		noError(t, err)
+		for _, path := range forbidden {
+			if slices.Contains(pkg.Imports, path) {
+				t.Errorf("%s: %s is imported", tag, path)
+			}
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package lexorank
+
+import (
+	"encoding/binary"
+	"fmt"
+	"unicode/utf8"
+)
+
+// MarshalMsgpack implements msgpack.Marshaler of github.com/vmihailenco/msgpack, encoding the key as a string.
+func (k Key) MarshalMsgpack() ([]byte, error) {
+	return appendMsgpackString(nil, string(k)), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler of github.com/vmihailenco/msgpack.
+// It returns an error if data is not a string or nil, or is not valid UTF-8.
+func (k *Key) UnmarshalMsgpack(data []byte) error {
+	s, err := readMsgpackString(data, "Key")
+	if err != nil {
+		return err
+	}
+	if !utf8.ValidString(s) {
+		return withCode(CodeInvalidKey, fmt.Errorf("invalid key %q: not valid UTF-8", s))
+	}
+	*k = Key(s)
+	return nil
+}
+
+// MarshalMsgpack implements msgpack.Marshaler of github.com/vmihailenco/msgpack, encoding the key as a string.
+// It returns a *BucketKeyError if the key is not in the format "bucket|key".
+func (k BucketKey) MarshalMsgpack() ([]byte, error) {
+	if err := k.validateFormat(); err != nil {
+		return nil, err
+	}
+	return appendMsgpackString(nil, string(k)), nil
+}
+
+// UnmarshalMsgpack implements msgpack.Unmarshaler of github.com/vmihailenco/msgpack.
+// It returns an error if data is not a string or nil, is not valid UTF-8, or is not in the format "bucket|key".
+func (k *BucketKey) UnmarshalMsgpack(data []byte) error {
+	s, err := readMsgpackString(data, "BucketKey")
+	if err != nil {
+		return err
+	}
+	if !utf8.ValidString(s) {
+		return withCode(CodeInvalidKey, fmt.Errorf("invalid bucket key %q: not valid UTF-8", s))
+	}
+	return k.set(s)
+}
+
+// appendMsgpackString appends s in the smallest str format of MessagePack.
+func appendMsgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n <= 31:
+		b = append(b, 0xa0|byte(n))
+	case n <= 0xff:
+		b = append(b, 0xd9, byte(n))
+	case n <= 0xffff:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	default:
+		b = binary.BigEndian.AppendUint32(append(b, 0xdb), uint32(n))
+	}
+	return append(b, s...)
+}
+
+// readMsgpackString reads a MessagePack value of the str or bin format, or nil as an empty string.
+func readMsgpackString(data []byte, name string) (string, error) {
+	invalid := withCode(CodeInvalidArgument, fmt.Errorf("cannot decode msgpack %x into %s", data, name))
+	if len(data) == 0 {
+		return "", invalid
+	}
+	var n, header int
+	switch c := data[0]; {
+	case c == 0xc0:
+		n, header = 0, 1
+	case c&0xe0 == 0xa0:
+		n, header = int(c&0x1f), 1
+	case (c == 0xd9 || c == 0xc4) && len(data) >= 2:
+		n, header = int(data[1]), 2
+	case (c == 0xda || c == 0xc5) && len(data) >= 3:
+		n, header = int(binary.BigEndian.Uint16(data[1:])), 3
+	case (c == 0xdb || c == 0xc6) && len(data) >= 5:
+		n, header = int(binary.BigEndian.Uint32(data[1:])), 5
+	default:
+		return "", invalid
+	}
+	if len(data) != header+n {
+		return "", invalid
+	}
+	return string(data[header:]), nil
+}
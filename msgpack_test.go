@@ -0,0 +1,64 @@
+package lexorank
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestKey_Msgpack(t *testing.T) {
+	for _, tt := range []struct {
+		key  Key
+		want []byte
+	}{
+		{"", []byte{0xa0}},
+		{"abc", []byte{0xa3, 'a', 'b', 'c'}},
+		{Key(strings.Repeat("a", 32)), append([]byte{0xd9, 32}, strings.Repeat("a", 32)...)},
+		{Key(strings.Repeat("a", 256)), append([]byte{0xda, 0x01, 0x00}, strings.Repeat("a", 256)...)},
+	} {
+		data, err := tt.key.MarshalMsgpack()
+		noError(t, err)
+		if !bytes.Equal(data, tt.want) {
+			t.Fatalf("%q: expected %x, got %x", tt.key, tt.want, data)
+		}
+		var k Key
+		noError(t, k.UnmarshalMsgpack(data))
+		equalKey(t, k, tt.key)
+	}
+
+	var k Key
+	noError(t, k.UnmarshalMsgpack([]byte{0xc4, 0x01, 'a'}))
+	equalKey(t, k, "a")
+	noError(t, k.UnmarshalMsgpack([]byte{0xc0}))
+	equalKey(t, k, "")
+
+	for _, data := range [][]byte{nil, {0x01}, {0xa3, 'a'}, {0xa1, 0xff}} {
+		if err := k.UnmarshalMsgpack(data); err == nil {
+			t.Fatalf("%x: expected error, but got nil", data)
+		}
+	}
+}
+
+func TestBucketKey_Msgpack(t *testing.T) {
+	data, err := BucketKey("0|abc").MarshalMsgpack()
+	noError(t, err)
+	var k BucketKey
+	noError(t, k.UnmarshalMsgpack(data))
+	equalBucketKey(t, k, "0|abc")
+
+	data, err = BucketKey("0:abc").MarshalMsgpack()
+	noError(t, err)
+	noError(t, k.UnmarshalMsgpack(data))
+	equalBucketKey(t, k, "0:abc")
+
+	if err := k.UnmarshalMsgpack([]byte{0xa3, '0', '|', 0xff}); err == nil {
+		t.Fatal("expected error for invalid UTF-8")
+	}
+	if _, err := BucketKey("abc").MarshalMsgpack(); err == nil {
+		t.Fatal("expected error for invalid format")
+	}
+	if err := k.UnmarshalMsgpack([]byte{0xa3, 'a', 'b', 'c'}); err == nil {
+		t.Fatal("expected error for invalid format")
+	}
+	equalBucketKey(t, k, "0:abc")
+}
@@ -0,0 +1,30 @@
+package lexorank
+
+import "fmt"
+
+// WithMudderSubdivision returns a GeneratorOption that generates keys like mudder.js: the longest common prefix
+// of the keys is kept, and the rest of the gap is subdivided proportionally with the shortest length that has
+// room for a key, which produces shorter keys than the default under many insertion patterns.
+// It is a shorthand for WithAlgorithm(MudderAlgorithm).
+func WithMudderSubdivision() GeneratorOption {
+	return WithAlgorithm(MudderAlgorithm)
+}
+
+func (g *Generator) betweenMudder(prevKey, nextKey Key) (Key, error) {
+	if nextKey != "" && prevKey >= nextKey {
+		return "", withCode(CodeOrderViolation, fmt.Errorf("prevKey (%q) must be strictly less than nextKey (%q)", prevKey, nextKey))
+	}
+	var prefix []rune
+	if nextKey != "" {
+		prevRunes, nextRunes := []rune(prevKey), []rune(nextKey)
+		for i := 0; i < len(prevRunes) && i < len(nextRunes) && prevRunes[i] == nextRunes[i]; i++ {
+			prefix = append(prefix, prevRunes[i])
+		}
+	}
+	n := len(string(prefix))
+	keys, err := spreadWithLength(newKeyDigits(g.characterSet), prevKey[n:], nextKey[n:], 1)
+	if err != nil {
+		return "", err
+	}
+	return Key(prefix) + keys[0], nil
+}
@@ -0,0 +1,46 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+func TestWithMudderSubdivision(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithMudderSubdivision())
+
+	for _, tt := range []struct {
+		prev Key
+		next Key
+		want Key
+	}{
+		{"", "", "5"},
+		{"5", "", "8"},
+		{"", "5", "3"},
+		{"5", "6", "55"},
+		{"1", "19", "15"},
+		{"123", "129", "126"},
+	} {
+		t.Run(fmt.Sprintf("%s_%s", tt.prev, tt.next), func(t *testing.T) {
+			key, err := g.Between(tt.prev, tt.next)
+			noError(t, err)
+			equalKey(t, key, tt.want)
+		})
+	}
+
+	if _, err := g.Between("5", "5"); err == nil {
+		t.Fatal("expected error for equal keys")
+	}
+	for _, keys := range [][2]Key{{"5", "50"}, {"5", "500"}, {"", "0"}} {
+		if _, err := g.Between(keys[0], keys[1]); !errors.Is(err, ErrGapExhausted) {
+			t.Fatalf("%q: expected ErrGapExhausted, got %v", keys, err)
+		}
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		testRecursive(t, NewGenerator(WithMudderSubdivision()), "", "", 12)
+	})
+}
@@ -0,0 +1,10 @@
+package lexorank
+
+// WithObserver returns a GeneratorOption that sets a function called with the result of every Between call,
+// including Next, Prev and Initial, for collecting statistics. The function must be safe for concurrent use
+// if the Generator is used concurrently.
+func WithObserver(f func(key Key, err error)) GeneratorOption {
+	return func(g *Generator) {
+		g.observer = f
+	}
+}
@@ -0,0 +1,60 @@
+package lexorank
+
+import "errors"
+
+// WithPinnedRanges returns a GeneratorOption that reserves the keys less than top and the keys greater than bottom
+// for pinned items. Between and the other functions generating keys, such as BetweenWithLength, AssignRanks and
+// the Rebalancer, never generate keys in the reserved ranges, while PinTop and PinBottom generate keys only in them. An empty top or bottom reserves nothing at the start or the end respectively.
+// The initial key must be in the range between top and bottom.
+func WithPinnedRanges(top, bottom Key) GeneratorOption {
+	return func(g *Generator) {
+		g.pinnedTop = top
+		g.pinnedBottom = bottom
+	}
+}
+
+// PinTop generates a key between the keys within the range reserved at the start by WithPinnedRanges.
+// nextKey beyond the range is treated as the end of the range.
+func (g *Generator) PinTop(prevKey, nextKey Key) (Key, error) {
+	if g.pinnedTop == "" {
+		return "", withCode(CodeInvalidArgument, errors.New("no range is reserved at the start"))
+	}
+	// Reserving the keys greater than top leaves only the range at the start to Between.
+	pinned := *g
+	pinned.pinnedTop, pinned.pinnedBottom = "", g.pinnedTop
+	return pinned.Between(prevKey, nextKey)
+}
+
+// PinBottom generates a key between the keys within the range reserved at the end by WithPinnedRanges.
+// prevKey beyond the range is treated as the start of the range.
+func (g *Generator) PinBottom(prevKey, nextKey Key) (Key, error) {
+	if g.pinnedBottom == "" {
+		return "", withCode(CodeInvalidArgument, errors.New("no range is reserved at the end"))
+	}
+	// Reserving the keys less than bottom leaves only the range at the end to Between.
+	pinned := *g
+	pinned.pinnedTop, pinned.pinnedBottom = g.pinnedBottom, ""
+	return pinned.Between(prevKey, nextKey)
+}
+
+// excludePinned narrows the keys to the range not reserved for pinned items.
+// Empty keys are kept if the initial key is in the range, so that the initial key is generated for them.
+func (g *Generator) excludePinned(prevKey, nextKey Key) (Key, Key) {
+	initial := Key(g.initial)
+	if prevKey == "" && nextKey == "" && initial >= g.pinnedTop && (g.pinnedBottom == "" || initial <= g.pinnedBottom) {
+		return prevKey, nextKey
+	}
+	return g.clampPinned(prevKey, nextKey)
+}
+
+// clampPinned narrows the keys to the range not reserved for pinned items, treating empty keys as the start and
+// the end of the keyspace.
+func (g *Generator) clampPinned(prevKey, nextKey Key) (Key, Key) {
+	if g.pinnedTop != "" && prevKey < g.pinnedTop {
+		prevKey = g.pinnedTop
+	}
+	if g.pinnedBottom != "" && (nextKey == "" || nextKey > g.pinnedBottom) {
+		nextKey = g.pinnedBottom
+	}
+	return prevKey, nextKey
+}
@@ -0,0 +1,96 @@
+package lexorank
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestWithPinnedRanges(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithPinnedRanges("1", "9"))
+
+	for _, tt := range []struct {
+		name string
+		f    func(prev, next Key) (Key, error)
+		prev Key
+		next Key
+		want Key
+	}{
+		{"initial", g.Between, "", "", "5"},
+		{"next", g.Between, "8", "", "84"},
+		{"prev", g.Between, "", "2", "14"},
+		{"after pinned", g.Between, "05", "2", "14"},
+		{"pin top", g.PinTop, "", "5", "0"},
+		{"pin top after pinned", g.PinTop, "05", "", "07"},
+		{"pin bottom", g.PinBottom, "5", "", "91"},
+		{"pin bottom before pinned", g.PinBottom, "", "95", "92"},
+		{"pin top empty", g.PinTop, "", "", "0"},
+		{"pin bottom empty", g.PinBottom, "", "", "91"},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := tt.f(tt.prev, tt.next)
+			noError(t, err)
+			equalKey(t, key, tt.want)
+		})
+	}
+
+	if _, err := NewGenerator().PinTop("", ""); err == nil {
+		t.Fatal("expected error without pinned ranges")
+	}
+
+	t.Run("through Between", func(t *testing.T) {
+		var observed []Key
+		g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithPinnedRanges("1", "9"),
+			WithKeyPrefix("p_"), WithObserver(func(key Key, err error) { observed = append(observed, key) }))
+
+		top, err := g.PinTop("", "p_5")
+		noError(t, err)
+		equalKey(t, top, "p_0")
+		bottom, err := g.PinBottom("p_5", "")
+		noError(t, err)
+		equalKey(t, bottom, "p_91")
+		if len(observed) != 2 || observed[0] != top || observed[1] != bottom {
+			t.Fatalf("unexpected observed keys: %v", observed)
+		}
+
+		var be *BetweenError
+		if _, err := g.PinTop("5", ""); !errors.As(err, &be) {
+			t.Fatalf("expected BetweenError, got %v", err)
+		}
+	})
+	t.Run("other entry points", func(t *testing.T) {
+		outside := func(t *testing.T, keys ...Key) {
+			t.Helper()
+			for _, key := range keys {
+				if key <= "1" || key >= "9" {
+					t.Fatalf("%q is in a reserved range", key)
+				}
+			}
+		}
+
+		keys, err := AssignRanks(g, make([]int, 20))
+		noError(t, err)
+		outside(t, keys...)
+		outside(t, slices.Collect(g.InitialSeq(20))...)
+		positions, err := PositionsToKeys(g, []int{3, 1, 2})
+		noError(t, err)
+		outside(t, positions...)
+
+		key, err := g.BetweenWithLength("", "", 3)
+		noError(t, err)
+		outside(t, key)
+		key, err = g.BetweenWithLength("0", "95", 3)
+		noError(t, err)
+		outside(t, key)
+
+		r, err := NewRebalancer(g)
+		noError(t, err)
+		for _, key := range r.Rebalance(slices.Values(make([]Key, 10))) {
+			outside(t, key)
+		}
+		noError(t, r.Err())
+	})
+}
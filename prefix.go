@@ -0,0 +1,95 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// WithKeyPrefix returns a GeneratorOption that makes every key generated by the Generator start with prefix,
+// e.g. "rnk_", including the keys of PinTop, BetweenWithLength, Add, KeyAtFraction, SliceKeyspace, AssignRanks,
+// Audit and the Rebalancer. Keys given to the Generator must start with the prefix, which is stripped before
+// generation, and Density and Forecast measure keys without the prefix.
+func WithKeyPrefix(prefix string) GeneratorOption {
+	return func(g *Generator) {
+		g.keyPrefix = prefix
+	}
+}
+
+// KeyPrefix returns the prefix of keys generated by the Generator.
+func (g *Generator) KeyPrefix() string {
+	return g.keyPrefix
+}
+
+// betweenPrefixed strips the key prefix from the keys, generates a key between them and prefixes the result.
+func (g *Generator) betweenPrefixed(prevKey, nextKey Key) (Key, error) {
+	var err error
+	if prevKey, err = g.stripPrefix(prevKey); err != nil {
+		return "", err
+	}
+	if nextKey, err = g.stripPrefix(nextKey); err != nil {
+		return "", err
+	}
+	unprefixed := *g
+	unprefixed.keyPrefix = ""
+	key, err := unprefixed.betweenKeys(prevKey, nextKey)
+	if key == "" {
+		return "", err
+	}
+	return Key(g.keyPrefix) + key, err
+}
+
+// unprefixed returns a copy of the Generator without the key prefix and the keys stripped of the prefix.
+func (g *Generator) unprefixed(keys []Key) (*Generator, []Key, error) {
+	stripped := make([]Key, len(keys))
+	for i, key := range keys {
+		var err error
+		if stripped[i], err = g.stripPrefix(key); err != nil {
+			return nil, nil, err
+		}
+	}
+	unprefixed := *g
+	unprefixed.keyPrefix = ""
+	return &unprefixed, stripped, nil
+}
+
+// addPrefix prefixes the key generated without the key prefix. An empty key stays empty.
+func (g *Generator) addPrefix(key Key) Key {
+	if key == "" {
+		return ""
+	}
+	return Key(g.keyPrefix) + key
+}
+
+// rawKey strips the key prefix and the signature from the key given to the functions that generate keys outside of
+// Between, such as BetweenWithLength and the Rebalancer.
+func (g *Generator) rawKey(key Key) (Key, error) {
+	key, err := g.stripPrefix(key)
+	if err != nil {
+		return "", err
+	}
+	return g.unsignKey(key), nil
+}
+
+// finishKey signs and prefixes the key generated outside of Between, so that it looks like a key of Between.
+func (g *Generator) finishKey(key Key) (Key, error) {
+	key, err := g.signKey(key)
+	if err != nil {
+		return "", err
+	}
+	return g.addPrefix(key), nil
+}
+
+func (g *Generator) stripPrefix(key Key) (Key, error) {
+	if key == "" {
+		return "", nil
+	}
+	stripped, ok := strings.CutPrefix(string(key), g.keyPrefix)
+	if !ok {
+		return "", withCode(CodeInvalidKey, fmt.Errorf("invalid key %q: prefix %q not found", key, g.keyPrefix))
+	}
+	if stripped == "" {
+		return "", withCode(CodeInvalidKey, errors.New("invalid key: nothing follows the prefix"))
+	}
+	return Key(stripped), nil
+}
@@ -0,0 +1,108 @@
+package lexorank
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWithKeyPrefix(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithKeyPrefix("rnk_"))
+
+	key, err := g.Initial()
+	noError(t, err)
+	equalKey(t, key, "rnk_5")
+
+	key, err = g.Next(key)
+	noError(t, err)
+	equalKey(t, key, "rnk_6")
+
+	key, err = g.Between("rnk_5", "rnk_6")
+	noError(t, err)
+	equalKey(t, key, "rnk_54")
+
+	for _, key := range []Key{"5", "rnk_", "rk_5"} {
+		if _, err := g.Next(key); ErrorCode(err) != CodeInvalidKey {
+			t.Fatalf("%q: expected invalid key, got %v", key, err)
+		}
+	}
+}
+
+func TestWithKeyPrefix_EntryPoints(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithKeyPrefix("rnk_"), WithPinnedRanges("1", "9"))
+	hasPrefix := func(t *testing.T, keys ...Key) {
+		t.Helper()
+		for _, key := range keys {
+			if !strings.HasPrefix(string(key), "rnk_") {
+				t.Fatalf("%q has no prefix", key)
+			}
+		}
+	}
+
+	top, err := g.PinTop("", "")
+	noError(t, err)
+	bottom, err := g.PinBottom("", "")
+	noError(t, err)
+	hasPrefix(t, top, bottom)
+
+	key, err := g.BetweenWithLength("rnk_5", "rnk_6", 2)
+	noError(t, err)
+	equalKey(t, key, "rnk_55")
+	key, err = g.Compact("rnk_555", "rnk_5", "rnk_6")
+	noError(t, err)
+	equalKey(t, key, "rnk_55")
+
+	keys, err := AssignRanks(g, []int{1, 2})
+	noError(t, err)
+	hasPrefix(t, keys...)
+	hasPrefix(t, slices.Collect(g.InitialSeq(2))...)
+
+	report, err := g.Audit([]Key{"rnk_5", "rnk_3", "5"})
+	noError(t, err)
+	for _, repair := range report.Repairs {
+		hasPrefix(t, repair.New)
+	}
+
+	r, err := NewRebalancer(g)
+	noError(t, err)
+	for _, key := range r.Rebalance(slices.Values([]Key{"rnk_5", "rnk_55"})) {
+		hasPrefix(t, key)
+	}
+	noError(t, r.Err())
+	// The length of the keys is measured without the prefix.
+	n := 0
+	for _, key := range r.RebalanceLong(slices.Values([]Key{"rnk_5", "rnk_5555", "rnk_6"}), 3) {
+		hasPrefix(t, key)
+		n++
+	}
+	noError(t, r.Err())
+	if n != 1 {
+		t.Fatalf("expected 1 rebalanced key, got %d", n)
+	}
+	key, err = g.Add("rnk_5", 1, 2)
+	noError(t, err)
+	equalKey(t, key, "rnk_51")
+	key, err = g.KeyAtFraction(0.5)
+	noError(t, err)
+	equalKey(t, key, "rnk_5")
+	bounds, err := g.SliceKeyspace(4)
+	noError(t, err)
+	hasPrefix(t, bounds...)
+
+	density, err := g.Density("rnk_5", "rnk_6", []Key{"rnk_55"})
+	noError(t, err)
+	if density.Length != 2 || density.Used != 1 {
+		t.Fatalf("unexpected density: %+v", density)
+	}
+	remaining, err := g.Forecast([]Key{"rnk_5"}, InsertAtTail, 1)
+	noError(t, err)
+	if remaining.Int64() != 4 {
+		t.Fatalf("expected 4, got %v", remaining)
+	}
+}
@@ -0,0 +1,67 @@
+package lexorank
+
+import (
+	"fmt"
+	mathrand "math/rand"
+	"math/rand/v2"
+	"reflect"
+)
+
+// RandomKey returns a random key of 1 to maxLength characters from the character set.
+// The key never ends with the min character of the set, so that a key can always be generated
+// before it and between it and any other key returned by RandomKey.
+// It returns an error if the character set has fewer than two characters.
+func RandomKey(r *rand.Rand, set CharacterSet, maxLength int) (Key, error) {
+	runes := characterSetRunes(set)
+	if len(runes) < 2 {
+		return "", withCode(CodeInvalidCharacterSet, fmt.Errorf("invalid character set: %d characters, but at least 2 are required", len(runes)))
+	}
+	n := 1
+	if maxLength > 1 {
+		n += r.IntN(maxLength)
+	}
+	key := make([]rune, n)
+	for i := range key {
+		key[i] = runes[r.IntN(len(runes))]
+	}
+	// Exclude the min character from the last position.
+	key[n-1] = runes[1+r.IntN(len(runes)-1)]
+	return Key(key), nil
+}
+
+// RandomKeyPair returns two random keys prev and next such that prev < next.
+// See RandomKey for the properties of each key and the error.
+func RandomKeyPair(r *rand.Rand, set CharacterSet, maxLength int) (prev, next Key, err error) {
+	for {
+		a, err := RandomKey(r, set, maxLength)
+		if err != nil {
+			return "", "", err
+		}
+		b, err := RandomKey(r, set, maxLength)
+		if err != nil {
+			return "", "", err
+		}
+		switch {
+		case a < b:
+			return a, b, nil
+		case a > b:
+			return b, a, nil
+		}
+	}
+}
+
+// Generate implements testing/quick.Generator.
+// It returns a random key from DefaultCharacterSet with at most size characters.
+func (Key) Generate(r *mathrand.Rand, size int) reflect.Value {
+	// DefaultCharacterSet has enough characters for RandomKey.
+	key, _ := RandomKey(rand.New(r), DefaultCharacterSet, size)
+	return reflect.ValueOf(key)
+}
+
+func characterSetRunes(set CharacterSet) []rune {
+	runes := []rune{set.Min()}
+	for r, ok := set.Next(set.Min()); ok; r, ok = set.Next(r) {
+		runes = append(runes, r)
+	}
+	return runes
+}
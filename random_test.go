@@ -0,0 +1,57 @@
+package lexorank
+
+import (
+	"math/rand/v2"
+	"testing"
+	"testing/quick"
+)
+
+func TestRandomKeyPair(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+	r := rand.New(rand.NewPCG(1, 2))
+
+	for i := 0; i < 1000; i++ {
+		prev, next, err := RandomKeyPair(r, charSet, 5)
+		noError(t, err)
+		testRecursive(t, g, prev, next, 3)
+
+		key, err := g.Prev(prev)
+		noError(t, err)
+		validateKey(t, key, "", prev)
+		key, err = g.Next(next)
+		noError(t, err)
+		validateKey(t, key, next, "")
+	}
+}
+
+func TestRandomKey_Error(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0")
+	noError(t, err)
+
+	r := rand.New(rand.NewPCG(1, 2))
+	if _, err := RandomKey(r, charSet, 5); ErrorCode(err) != CodeInvalidCharacterSet {
+		t.Fatalf("expected CodeInvalidCharacterSet, got %v", err)
+	}
+	if _, _, err := RandomKeyPair(r, charSet, 5); ErrorCode(err) != CodeInvalidCharacterSet {
+		t.Fatalf("expected CodeInvalidCharacterSet, got %v", err)
+	}
+}
+
+func TestKey_Generate(t *testing.T) {
+	g := NewGenerator()
+
+	err := quick.Check(func(a, b Key) bool {
+		if a == b {
+			return true
+		}
+		if a > b {
+			a, b = b, a
+		}
+		key, err := g.Between(a, b)
+		return err == nil && a < key && key < b
+	}, nil)
+	noError(t, err)
+}
@@ -0,0 +1,254 @@
+package lexorank
+
+import (
+	"cmp"
+	"fmt"
+	"iter"
+	"slices"
+)
+
+// Ranked is an ID with its Key.
+type Ranked[ID comparable] struct {
+	ID  ID  `json:"id"`
+	Key Key `json:"key"`
+}
+
+// RankedMap maps IDs to Keys and keeps them sorted by Key.
+// An ID can be hidden by Hide, which removes it from the order but keeps its key for Unhide.
+// RankedMap is not safe for concurrent use.
+type RankedMap[ID comparable] struct {
+	generator *Generator
+	keys      map[ID]Key
+	hidden    map[ID]Key
+	sorted    []Ranked[ID]
+	onChange  func(ID, KeyChange)
+}
+
+// NewRankedMap creates a new empty RankedMap that generates keys with the Generator.
+func NewRankedMap[ID comparable](g *Generator) *RankedMap[ID] {
+	return &RankedMap[ID]{
+		g,
+		map[ID]Key{},
+		map[ID]Key{},
+		nil,
+		func(ID, KeyChange) {},
+	}
+}
+
+// OnChange sets a function called whenever the key of an ID changes by Set, Delete or an insertion.
+// Old is empty for an added ID and New is empty for a deleted ID. Restore and Hide do not call the function.
+func (m *RankedMap[ID]) OnChange(f func(id ID, change KeyChange)) {
+	m.onChange = f
+}
+
+// Len returns the number of IDs in the map, not counting hidden IDs.
+func (m *RankedMap[ID]) Len() int {
+	return len(m.sorted)
+}
+
+// Key returns the key of the ID, including a hidden ID.
+func (m *RankedMap[ID]) Key(id ID) (Key, bool) {
+	if key, ok := m.hidden[id]; ok {
+		return key, true
+	}
+	key, ok := m.keys[id]
+	return key, ok
+}
+
+// Set sets the key of the ID, such as a key loaded from a database. A hidden ID is unhidden.
+func (m *RankedMap[ID]) Set(id ID, key Key) {
+	old, _ := m.Key(id)
+	m.set(id, key)
+	if old != key {
+		m.onChange(id, KeyChange{old, key})
+	}
+}
+
+func (m *RankedMap[ID]) set(id ID, key Key) {
+	m.delete(id)
+	delete(m.hidden, id)
+	m.keys[id] = key
+	i, _ := slices.BinarySearchFunc(m.sorted, key, compareRankedKey)
+	m.sorted = slices.Insert(m.sorted, i, Ranked[ID]{id, key})
+}
+
+// Delete removes the ID from the map, whether it is hidden or not.
+func (m *RankedMap[ID]) Delete(id ID) {
+	if old, ok := m.Key(id); ok {
+		m.delete(id)
+		delete(m.hidden, id)
+		m.onChange(id, KeyChange{old, ""})
+	}
+}
+
+// Hide removes the ID from the order, i.e. All, NeighborsOf, Snapshot and the neighbors of insertions,
+// but keeps its key so that Unhide can put it back, e.g. for archiving an item.
+// It returns false if the ID is not in the map or is already hidden.
+func (m *RankedMap[ID]) Hide(id ID) bool {
+	key, ok := m.keys[id]
+	if !ok {
+		return false
+	}
+	m.delete(id)
+	m.hidden[id] = key
+	return true
+}
+
+// Unhide puts the hidden ID back at its old key. If another ID has taken the key in the meantime,
+// the ID is placed right after that ID with a new key instead.
+// It returns an error with CodeInvalidArgument if the ID is not hidden.
+func (m *RankedMap[ID]) Unhide(id ID) (Key, error) {
+	key, ok := m.hidden[id]
+	if !ok {
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("%v is not hidden", id))
+	}
+	i, found := slices.BinarySearchFunc(m.sorted, key, compareRankedKey)
+	if !found {
+		m.set(id, key)
+		return key, nil
+	}
+	return m.move(id, func() (Key, Key) {
+		for i < len(m.sorted) && m.sorted[i].Key == key {
+			i++
+		}
+		var next Key
+		if i < len(m.sorted) {
+			next = m.sorted[i].Key
+		}
+		return key, next
+	})
+}
+
+func (m *RankedMap[ID]) delete(id ID) {
+	i, ok := m.index(id)
+	if !ok {
+		return
+	}
+	delete(m.keys, id)
+	m.sorted = slices.Delete(m.sorted, i, i+1)
+}
+
+// NeighborsOf returns the IDs before and after the ID in the order of keys.
+// A missing neighbor is the zero value, whose Key is empty.
+// It returns false if the ID is not in the map.
+func (m *RankedMap[ID]) NeighborsOf(id ID) (prev, next Ranked[ID], ok bool) {
+	i, ok := m.index(id)
+	if !ok {
+		return prev, next, false
+	}
+	if i > 0 {
+		prev = m.sorted[i-1]
+	}
+	if i < len(m.sorted)-1 {
+		next = m.sorted[i+1]
+	}
+	return prev, next, true
+}
+
+// InsertAfter places the ID right after the ID after, generating a new key for it.
+// If the ID is already in the map, it is moved.
+func (m *RankedMap[ID]) InsertAfter(id, after ID) (Key, error) {
+	return m.insertNextTo(id, after, 1)
+}
+
+// InsertBefore places the ID right before the ID before, generating a new key for it.
+// If the ID is already in the map, it is moved.
+func (m *RankedMap[ID]) InsertBefore(id, before ID) (Key, error) {
+	return m.insertNextTo(id, before, 0)
+}
+
+func (m *RankedMap[ID]) insertNextTo(id, target ID, offset int) (Key, error) {
+	if id == target {
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("cannot insert %v next to itself", id))
+	}
+	if _, ok := m.keys[target]; !ok {
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("%v is not in the map", target))
+	}
+	return m.move(id, func() (Key, Key) {
+		i, _ := m.index(target)
+		i += offset
+		var prev, next Key
+		if i > 0 {
+			prev = m.sorted[i-1].Key
+		}
+		if i < len(m.sorted) {
+			next = m.sorted[i].Key
+		}
+		return prev, next
+	})
+}
+
+// Append places the ID at the end, generating a new key for it.
+// If the ID is already in the map, it is moved.
+func (m *RankedMap[ID]) Append(id ID) (Key, error) {
+	return m.move(id, func() (Key, Key) {
+		if len(m.sorted) == 0 {
+			return "", ""
+		}
+		return m.sorted[len(m.sorted)-1].Key, ""
+	})
+}
+
+// move removes the ID from the map and sets a key between the neighbors returned by neighbors.
+// The ID is restored, hidden or not, if no key can be generated.
+func (m *RankedMap[ID]) move(id ID, neighbors func() (Key, Key)) (Key, error) {
+	oldKey, exists := m.keys[id]
+	m.delete(id)
+	key, err := m.generator.Between(neighbors())
+	if err != nil {
+		if exists {
+			m.set(id, oldKey)
+		}
+		return "", err
+	}
+	if hiddenKey, ok := m.hidden[id]; ok {
+		oldKey = hiddenKey
+	}
+	m.set(id, key)
+	m.onChange(id, KeyChange{oldKey, key})
+	return key, nil
+}
+
+// All returns an iterator over the IDs and their keys in the order of keys.
+func (m *RankedMap[ID]) All() iter.Seq2[ID, Key] {
+	return func(yield func(ID, Key) bool) {
+		for _, r := range m.sorted {
+			if !yield(r.ID, r.Key) {
+				return
+			}
+		}
+	}
+}
+
+// Snapshot returns the IDs and their keys in the order of keys.
+func (m *RankedMap[ID]) Snapshot() []Ranked[ID] {
+	return slices.Clone(m.sorted)
+}
+
+// Restore replaces the content of the map with the snapshot. Hidden IDs are removed.
+func (m *RankedMap[ID]) Restore(snapshot []Ranked[ID]) {
+	m.keys = make(map[ID]Key, len(snapshot))
+	m.hidden = map[ID]Key{}
+	m.sorted = m.sorted[:0]
+	for _, r := range snapshot {
+		m.set(r.ID, r.Key)
+	}
+}
+
+func (m *RankedMap[ID]) index(id ID) (int, bool) {
+	key, ok := m.keys[id]
+	if !ok {
+		return 0, false
+	}
+	i, _ := slices.BinarySearchFunc(m.sorted, key, compareRankedKey)
+	for ; i < len(m.sorted) && m.sorted[i].Key == key; i++ {
+		if m.sorted[i].ID == id {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func compareRankedKey[ID comparable](r Ranked[ID], key Key) int {
+	return cmp.Compare(r.Key, key)
+}
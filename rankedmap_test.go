@@ -0,0 +1,184 @@
+package lexorank
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRankedMap(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	m := NewRankedMap[string](NewGenerator(WithCharacterSet(charSet), WithInitial("5")))
+	m.Set("b", "5")
+	m.Set("a", "3")
+
+	order := func() []string {
+		var ids []string
+		for id := range m.All() {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	key, err := m.Append("d")
+	noError(t, err)
+	equalKey(t, key, "6")
+
+	key, err = m.InsertAfter("c", "b")
+	noError(t, err)
+	equalKey(t, key, "54")
+
+	key, err = m.InsertBefore("z", "a")
+	noError(t, err)
+	equalKey(t, key, "2")
+
+	if got, want := order(), []string{"z", "a", "b", "c", "d"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	// Move an existing ID.
+	key, err = m.InsertAfter("z", "d")
+	noError(t, err)
+	equalKey(t, key, "7")
+	if got, want := order(), []string{"a", "b", "c", "d", "z"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	prev, next, ok := m.NeighborsOf("c")
+	if !ok || prev != (Ranked[string]{"b", "5"}) || next != (Ranked[string]{"d", "6"}) {
+		t.Fatalf("unexpected neighbors: %v %v %v", prev, next, ok)
+	}
+	prev, next, ok = m.NeighborsOf("a")
+	if !ok || prev != (Ranked[string]{}) || next.ID != "b" {
+		t.Fatalf("unexpected neighbors: %v %v %v", prev, next, ok)
+	}
+
+	m.Delete("c")
+	if _, ok := m.Key("c"); ok || m.Len() != 4 {
+		t.Fatalf("expected c to be deleted, got len %d", m.Len())
+	}
+
+	t.Run("error on missing target", func(t *testing.T) {
+		if _, err := m.InsertAfter("x", "missing"); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+
+	t.Run("restore on error", func(t *testing.T) {
+		m.Set("min", "00")
+		if _, err := m.InsertBefore("a", "min"); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+		key, ok := m.Key("a")
+		if !ok || key != "3" {
+			t.Fatalf("expected a to be restored, got %q", key)
+		}
+	})
+}
+
+func TestRankedMap_OnChange(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	m := NewRankedMap[string](NewGenerator(WithCharacterSet(charSet), WithInitial("5")))
+	var changes []Ranked[string]
+	m.OnChange(func(id string, change KeyChange) {
+		changes = append(changes, Ranked[string]{id, change.Old + ">" + change.New})
+	})
+
+	m.Set("a", "3")
+	m.Set("a", "3")
+	_, err = m.Append("b")
+	noError(t, err)
+	_, err = m.InsertBefore("b", "a")
+	noError(t, err)
+	m.Delete("a")
+	m.Delete("a")
+	m.Restore([]Ranked[string]{{"c", "1"}})
+
+	want := []Ranked[string]{
+		{"a", ">3"},
+		{"b", ">4"},
+		{"b", "4>2"},
+		{"a", "3>"},
+	}
+	if !slices.Equal(changes, want) {
+		t.Fatalf("expected %v, got %v", want, changes)
+	}
+}
+
+func TestRankedMap_Hide(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	m := NewRankedMap[string](NewGenerator(WithCharacterSet(charSet), WithInitial("5")))
+	var changes []Ranked[string]
+	m.OnChange(func(id string, change KeyChange) {
+		changes = append(changes, Ranked[string]{id, change.Old + ">" + change.New})
+	})
+	m.Set("a", "3")
+	m.Set("b", "5")
+	m.Set("c", "7")
+	changes = nil
+
+	order := func() []string {
+		var ids []string
+		for id := range m.All() {
+			ids = append(ids, id)
+		}
+		return ids
+	}
+
+	if !m.Hide("b") || m.Hide("b") || m.Hide("missing") {
+		t.Fatal("expected only the first Hide of b to succeed")
+	}
+	if got, want := order(), []string{"a", "c"}; !slices.Equal(got, want) || m.Len() != 2 {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if key, ok := m.Key("b"); !ok || key != "5" {
+		t.Fatalf("expected the key of hidden b to be kept, got %q", key)
+	}
+
+	// Unhide at the old key.
+	key, err := m.Unhide("b")
+	noError(t, err)
+	equalKey(t, key, "5")
+	if got, want := order(), []string{"a", "b", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if len(changes) != 0 {
+		t.Fatalf("expected no changes, got %v", changes)
+	}
+
+	// Unhide next to the ID that took the old key.
+	m.Hide("b")
+	m.Set("d", "5")
+	changes = nil
+	key, err = m.Unhide("b")
+	noError(t, err)
+	equalKey(t, key, "6")
+	if got, want := order(), []string{"a", "d", "b", "c"}; !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+	if want := []Ranked[string]{{"b", "5>6"}}; !slices.Equal(changes, want) {
+		t.Fatalf("expected %v, got %v", want, changes)
+	}
+
+	t.Run("error on not hidden", func(t *testing.T) {
+		if _, err := m.Unhide("a"); ErrorCode(err) != CodeInvalidArgument {
+			t.Fatalf("expected CodeInvalidArgument, got %v", err)
+		}
+	})
+
+	t.Run("delete hidden", func(t *testing.T) {
+		m.Hide("a")
+		m.Delete("a")
+		if _, ok := m.Key("a"); ok {
+			t.Fatal("expected a to be deleted")
+		}
+		if _, err := m.Unhide("a"); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
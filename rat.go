@@ -0,0 +1,71 @@
+package lexorank
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// KeyToRat returns the exact fraction in [0, 1) represented by key with respect to the character set.
+// Each character is a digit after the radix point whose value is its position in the set,
+// e.g. "5" is 1/2 and "05" is 1/20 with the character set "0123456789".
+// Keys that differ only in trailing min characters, such as "5" and "50", represent the same fraction.
+func KeyToRat(set CharacterSet, key Key) (*big.Rat, error) {
+	return newKeyDigits(set).toRat(key)
+}
+
+// RatToKey returns the key representing the fraction r in [0, 1) with respect to the character set.
+// If r cannot be represented exactly within maxLength characters, the result is truncated to the largest key
+// not greater than r. Trailing min characters are omitted, except for the key of fraction 0.
+func RatToKey(set CharacterSet, r *big.Rat, maxLength int) (Key, error) {
+	if r.Sign() < 0 || r.Cmp(big.NewRat(1, 1)) >= 0 {
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("fraction %s is out of range [0, 1)", r.RatString()))
+	}
+	if maxLength <= 0 {
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("maxLength must be positive: %d", maxLength))
+	}
+	d := newKeyDigits(set)
+	key := string(d.fromInt(d.truncate(r, maxLength), maxLength))
+	minChar := string(set.Min())
+	if trimmed := strings.TrimRight(key, minChar); trimmed != "" {
+		return Key(trimmed), nil
+	}
+	return Key(minChar), nil
+}
+
+// Float64 returns the approximate position of the key in [0, 1) with respect to the character set,
+// which is the fraction returned by KeyToRat rounded to a float64.
+// It is intended for dashboards and histograms: a float64 has 53 bits of precision, so characters beyond
+// about 9 with a 62-character set (or 16 with a 10-character set) do not change the result,
+// and nearby long keys may have the same position.
+func (k Key) Float64(set CharacterSet) (float64, error) {
+	d := newKeyDigits(set)
+	base := float64(len(d.runes))
+	runes := []rune(k)
+	position := 0.0
+	// Accumulating from the last character keeps the position of short keys exact, e.g. 0.75 for "75".
+	for i := len(runes) - 1; i >= 0; i-- {
+		digit, ok := d.index[runes[i]]
+		if !ok {
+			return 0, withCode(CodeInvalidKey, fmt.Errorf("invalid key: '%c' is not in the character set: %q", runes[i], k))
+		}
+		position = (float64(digit) + position) / base
+	}
+	return position, nil
+}
+
+// KeyAtFraction returns the key at approximately the fraction p in [0, 1) of the keyspace of the Generator,
+// e.g. 0.25 for a key a quarter of the way in. The key has at most as many characters as the initial key,
+// and is truncated to the largest such key not greater than p. See RatToKey.
+func (g *Generator) KeyAtFraction(p float64) (Key, error) {
+	r, ok := new(big.Rat).SetString(strconv.FormatFloat(p, 'g', -1, 64))
+	if !ok {
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("fraction %v is out of range [0, 1)", p))
+	}
+	key, err := RatToKey(g.characterSet, r, len([]rune(g.initial)))
+	if err != nil {
+		return "", err
+	}
+	return g.finishKey(key)
+}
@@ -0,0 +1,115 @@
+package lexorank
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+func TestKeyToRat(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	for key, want := range map[Key]*big.Rat{
+		"5":   big.NewRat(1, 2),
+		"50":  big.NewRat(1, 2),
+		"05":  big.NewRat(1, 20),
+		"125": big.NewRat(1, 8),
+		"":    big.NewRat(0, 1),
+	} {
+		got, err := KeyToRat(charSet, key)
+		noError(t, err)
+		if got.Cmp(want) != 0 {
+			t.Errorf("%q: expected %s, got %s", key, want, got)
+		}
+	}
+
+	if _, err := KeyToRat(charSet, "a"); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+}
+
+func TestRatToKey(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	for _, tt := range []struct {
+		rat  *big.Rat
+		want Key
+	}{
+		{big.NewRat(1, 2), "5"},
+		{big.NewRat(1, 8), "125"},
+		{big.NewRat(1, 3), "3333"},
+		{big.NewRat(0, 1), "0"},
+		{big.NewRat(1, 100000), "0"},
+	} {
+		got, err := RatToKey(charSet, tt.rat, 4)
+		noError(t, err)
+		equalKey(t, got, tt.want)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		for _, key := range []Key{"1", "123", "999", "0001"} {
+			r, err := KeyToRat(charSet, key)
+			noError(t, err)
+			got, err := RatToKey(charSet, r, 10)
+			noError(t, err)
+			equalKey(t, got, key)
+		}
+	})
+
+	for _, r := range []*big.Rat{big.NewRat(1, 1), big.NewRat(-1, 2)} {
+		if _, err := RatToKey(charSet, r, 4); err == nil {
+			t.Fatalf("%s: expected error, but got nil", r)
+		}
+	}
+}
+
+func TestKey_Float64(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	for key, want := range map[Key]float64{
+		"5":    0.5,
+		"25":   0.25,
+		"0":    0,
+		"9999": 0.9999,
+	} {
+		got, err := key.Float64(charSet)
+		noError(t, err)
+		if math.Abs(got-want) > 1e-12 {
+			t.Errorf("%q: expected %v, got %v", key, want, got)
+		}
+	}
+
+	if _, err := Key("a").Float64(charSet); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+}
+
+func TestGenerator_KeyAtFraction(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("555"))
+	for _, tt := range []struct {
+		p    float64
+		want Key
+	}{
+		{0.25, "25"},
+		{0.5, "5"},
+		{1.0 / 3, "333"},
+		{0.1, "1"},
+		{0, "0"},
+	} {
+		got, err := g.KeyAtFraction(tt.p)
+		noError(t, err)
+		equalKey(t, got, tt.want)
+	}
+
+	for _, p := range []float64{1, -0.5, math.NaN(), math.Inf(1)} {
+		if _, err := g.KeyAtFraction(p); err == nil {
+			t.Fatalf("%v: expected error, but got nil", p)
+		}
+	}
+}
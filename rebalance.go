@@ -0,0 +1,181 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"math/big"
+)
+
+// Rebalancer reassigns short, evenly spaced keys to existing keys whose lengths have grown.
+type Rebalancer struct {
+	generator *Generator
+	digits    keyDigits
+	start     *big.Int
+	length    int
+	err       error
+}
+
+// NewRebalancer creates a new Rebalancer that generates new keys with the Generator.
+func NewRebalancer(g *Generator) (*Rebalancer, error) {
+	d := newKeyDigits(g.characterSet)
+	length := len([]rune(g.initial))
+	start, err := d.toInt(Key(g.initial), length)
+	if err != nil {
+		return nil, err
+	}
+	return &Rebalancer{
+		g,
+		d,
+		start,
+		length,
+		nil,
+	}, nil
+}
+
+// Rebalance assigns a new key to each key in the order of keys and yields pairs of the old and the new key.
+// New keys start at the initial key of the Generator and leave room for insertions of one more character
+// between each other. Keys are consumed one at a time, so a table of any size can be rebalanced in constant memory.
+// If a new key cannot be generated, the iteration stops and the error is reported by Err.
+func (r *Rebalancer) Rebalance(keys iter.Seq[Key]) iter.Seq2[Key, Key] {
+	return assignKeys(r, keys)
+}
+
+// assignKeys assigns a new key to each item in the order of items as Rebalance does.
+func assignKeys[T any](r *Rebalancer, items iter.Seq[T]) iter.Seq2[T, Key] {
+	return func(yield func(T, Key) bool) {
+		r.err = nil
+		current := new(big.Int).Set(r.start)
+		maxValue := r.digits.max(r.length)
+		var prev Key
+		for item := range items {
+			var key, raw Key
+			if current.Cmp(maxValue) <= 0 {
+				raw = r.digits.fromInt(current, r.length)
+			}
+			if raw != "" && (r.generator.pinnedBottom == "" || raw < r.generator.pinnedBottom) {
+				var err error
+				if key, err = r.generator.finishKey(raw); err != nil {
+					r.err = err
+					return
+				}
+				current.Add(current, r.digits.base)
+			} else {
+				// The keys of the initial length are used up, or the rest are reserved for pinned items.
+				// Continue with longer keys.
+				var err error
+				key, err = r.generator.Next(prev)
+				if err != nil && !errors.Is(err, ErrKeySpaceDense) {
+					r.err = err
+					return
+				}
+			}
+			if !yield(item, key) {
+				return
+			}
+			prev = key
+		}
+	}
+}
+
+// RebalancePlan is the expected result of a rebalance.
+type RebalancePlan struct {
+	// Keys is the number of keys to be rebalanced.
+	Keys int
+	// Changes is the number of keys whose new key differs from the old key.
+	Changes int
+	// MaxLength is the length of the longest old key.
+	MaxLength int
+	// NewMaxLength is the length of the longest new key.
+	NewMaxLength int
+	// Batches is the number of batches to save the new keys in batches of the batch size.
+	Batches int
+}
+
+// Err returns the error that stopped the last Rebalance or RebalanceLong, if any.
+func (r *Rebalancer) Err() error {
+	return r.err
+}
+
+// Plan reports what Rebalance would do to keys without writing anything, so that it can be reviewed before running.
+// It returns the error reported by Err if Rebalance stops.
+func (r *Rebalancer) Plan(keys iter.Seq[Key], batchSize int) (RebalancePlan, error) {
+	var plan RebalancePlan
+	for old, key := range r.Rebalance(keys) {
+		plan.Keys++
+		if old != key {
+			plan.Changes++
+		}
+		plan.MaxLength = max(plan.MaxLength, len([]rune(old)))
+		plan.NewMaxLength = max(plan.NewMaxLength, len([]rune(key)))
+	}
+	if err := r.Err(); err != nil {
+		return RebalancePlan{}, err
+	}
+	if batchSize > 0 {
+		plan.Batches = (plan.Keys + batchSize - 1) / batchSize
+	}
+	return plan, nil
+}
+
+// RebalanceLong assigns new keys only to the keys longer than maxLength and yields pairs of the old and the new key.
+// The length is measured without the key prefix and the signature of WithHMAC.
+// Each run of long keys is respaced between its neighboring short keys, which stay unchanged, with the shortest keys
+// that fit. A run is left unchanged if a neighboring key has characters out of the character set, while the long
+// keys themselves are replaced whatever characters they have.
+// If keys are not sorted or a run has no room for new keys, the iteration stops and the error is reported by Err.
+func (r *Rebalancer) RebalanceLong(keys iter.Seq[Key], maxLength int) iter.Seq2[Key, Key] {
+	return func(yield func(Key, Key) bool) {
+		r.err = nil
+		var prev, last Key
+		var run []Key
+		flush := func(next Key) bool {
+			if len(run) == 0 {
+				return true
+			}
+			newKeys, err := spreadWithLength(r.digits, prev, next, len(run))
+			if ErrorCode(err) == CodeInvalidKey {
+				run = run[:0]
+				return true
+			}
+			if err != nil {
+				r.err = err
+				return false
+			}
+			for i, key := range newKeys {
+				if newKeys[i], err = r.generator.finishKey(key); err != nil {
+					r.err = err
+					return false
+				}
+			}
+			for i, old := range run {
+				if !yield(old, newKeys[i]) {
+					return false
+				}
+			}
+			run = run[:0]
+			return true
+		}
+		for key := range keys {
+			if last != "" && key <= last {
+				r.err = withCode(CodeOrderViolation, fmt.Errorf("keys must be sorted: %q comes after %q", key, last))
+				return
+			}
+			last = key
+			raw, err := r.generator.rawKey(key)
+			if err != nil {
+				r.err = err
+				return
+			}
+			if len([]rune(raw)) > maxLength {
+				run = append(run, key)
+				continue
+			}
+			if !flush(raw) {
+				return
+			}
+			prev = raw
+		}
+		flush("")
+	}
+}
@@ -0,0 +1,128 @@
+package lexorank
+
+import (
+	"maps"
+	"slices"
+	"testing"
+)
+
+func TestRebalancer_Rebalance(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("500"))
+	r, err := NewRebalancer(g)
+	noError(t, err)
+
+	old := []Key{"1", "15", "155", "1555", "15555", "2"}
+	var got []Key
+	for oldKey, newKey := range r.Rebalance(slices.Values(old)) {
+		equalKey(t, oldKey, old[len(got)])
+		got = append(got, newKey)
+	}
+	want := []Key{"500", "510", "520", "530", "540", "550"}
+	if !slices.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+
+	t.Run("overflow", func(t *testing.T) {
+		var last Key
+		n := 0
+		for _, newKey := range r.Rebalance(slices.Values(make([]Key, 100))) {
+			validateKey(t, newKey, last, "")
+			last = newKey
+			n++
+		}
+		if n != 100 {
+			t.Fatalf("expected 100 keys, got %d", n)
+		}
+		equalKey(t, last, "99999995")
+	})
+
+	t.Run("error on invalid initial", func(t *testing.T) {
+		if _, err := NewRebalancer(NewGenerator(WithCharacterSet(charSet), WithInitial("a"))); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
+
+func TestRebalancer_Plan(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	r, err := NewRebalancer(NewGenerator(WithCharacterSet(charSet), WithInitial("500")))
+	noError(t, err)
+
+	plan, err := r.Plan(slices.Values([]Key{"1", "15", "155", "1555", "15555", "550", "99"}), 3)
+	noError(t, err)
+	want := RebalancePlan{
+		Keys:         7,
+		Changes:      6,
+		MaxLength:    5,
+		NewMaxLength: 3,
+		Batches:      3,
+	}
+	if plan != want {
+		t.Fatalf("expected %+v, got %+v", want, plan)
+	}
+
+	t.Run("error on failed rebalance", func(t *testing.T) {
+		r, err := NewRebalancer(NewGenerator(WithCharacterSet(charSet), WithInitial("8"), WithAlgorithm(panicAlgorithm{})))
+		noError(t, err)
+		if _, err := r.Plan(slices.Values([]Key{"1", "2"}), 3); ErrorCode(err) != CodeInvariantViolation {
+			t.Fatalf("expected %s, got %v", CodeInvariantViolation, err)
+		}
+	})
+}
+
+func TestRebalancer_RebalanceLong(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	r, err := NewRebalancer(NewGenerator(WithCharacterSet(charSet)))
+	noError(t, err)
+
+	keys := []Key{"1", "1005", "1006", "10061", "2", "3", "35", "35555", "4", "99999"}
+	got := map[Key]Key{}
+	for oldKey, newKey := range r.RebalanceLong(slices.Values(keys), 2) {
+		got[oldKey] = newKey
+	}
+	want := map[Key]Key{
+		"1005":  "13",
+		"1006":  "15",
+		"10061": "17",
+		"35555": "38",
+		"99999": "7",
+	}
+	if !maps.Equal(got, want) {
+		t.Fatalf("expected %v, got %v", want, got)
+	}
+}
+
+func TestRebalancer_RebalanceLong_Error(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	r, err := NewRebalancer(NewGenerator(WithCharacterSet(charSet)))
+	noError(t, err)
+
+	for _, tt := range []struct {
+		keys []Key
+		code string
+	}{
+		{[]Key{"9", "1234567", "2"}, CodeOrderViolation},
+		{[]Key{"1", "3333333", "2"}, CodeOrderViolation},
+		{[]Key{"1", "5555555", "3333333", "6"}, CodeOrderViolation},
+	} {
+		for range r.RebalanceLong(slices.Values(tt.keys), 2) {
+			t.Fatalf("%q: unexpected key", tt.keys)
+		}
+		if ErrorCode(r.Err()) != tt.code {
+			t.Fatalf("%q: expected %s, got %v", tt.keys, tt.code, r.Err())
+		}
+	}
+
+	for range r.RebalanceLong(slices.Values([]Key{"1", "2"}), 2) {
+	}
+	noError(t, r.Err())
+}
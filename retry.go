@@ -0,0 +1,132 @@
+package lexorank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrConflict should be returned (or wrapped) by the save function of RetryBetween when the key conflicts with
+// a key saved concurrently, such as a unique constraint violation.
+var ErrConflict = withCode(CodeConflict, errors.New("key conflict"))
+
+// NeighborsFunc reads the current keys around the position to insert at.
+type NeighborsFunc func(ctx context.Context) (prev, next Key, err error)
+
+// SaveFunc saves the generated key. It returns an error wrapping ErrConflict to make RetryBetween retry.
+type SaveFunc func(ctx context.Context, key Key) error
+
+// RetryBetween reads neighbors, generates a key between them and saves it. When save fails with ErrConflict,
+// it waits for the backoff, re-reads the neighbors and tries again up to the max attempts.
+// This is the standard pattern for concurrent insertions at the same position.
+// It returns an error with CodeInvalidArgument if the max attempts is less than 1.
+func (g *Generator) RetryBetween(ctx context.Context, neighbors NeighborsFunc, save SaveFunc, opts ...RetryOption) (Key, error) {
+	r := &retry{
+		3,
+		defaultBackoff,
+	}
+	for _, opt := range opts {
+		opt(r)
+	}
+	if r.maxAttempts < 1 {
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("max attempts must be at least 1: %d", r.maxAttempts))
+	}
+
+	var lastErr error
+	for attempt := 1; attempt <= r.maxAttempts; attempt++ {
+		if attempt > 1 {
+			timer := time.NewTimer(r.backoff(attempt - 1))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return "", ctx.Err()
+			case <-timer.C:
+			}
+		}
+
+		prev, next, err := neighbors(ctx)
+		if err != nil {
+			return "", fmt.Errorf("failed to read neighbors: %w", err)
+		}
+		key, err := g.Between(prev, next)
+		if err != nil && !errors.Is(err, ErrKeySpaceDense) {
+			return "", err
+		}
+		if err := save(ctx, key); err != nil {
+			if !errors.Is(err, ErrConflict) {
+				return "", err
+			}
+			lastErr = err
+			continue
+		}
+		return key, nil
+	}
+	return "", fmt.Errorf("gave up after %d attempts: %w", r.maxAttempts, lastErr)
+}
+
+// InsertBatchFunc saves the items with their keys atomically, e.g. in one transaction.
+// It returns an error wrapping ErrConflict to make InsertBetween retry.
+type InsertBatchFunc[T any] func(ctx context.Context, items []T, keys []Key) error
+
+// InsertBetween reads neighbors, generates keys between them for the items by NBetween and saves all of them
+// at once. When save fails with ErrConflict, it re-reads the neighbors and tries once more, so that inserting a
+// block of items is safe against concurrent insertions at the same position.
+func InsertBetween[T any](ctx context.Context, g *Generator, neighbors NeighborsFunc, items []T, save InsertBatchFunc[T]) ([]Key, error) {
+	if len(items) == 0 {
+		return nil, nil
+	}
+	var lastErr error
+	for range 2 {
+		prev, next, err := neighbors(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read neighbors: %w", err)
+		}
+		keys, err := g.NBetween(prev, next, len(items))
+		if err != nil {
+			return nil, err
+		}
+		if err := save(ctx, items, keys); err != nil {
+			if !errors.Is(err, ErrConflict) {
+				return nil, err
+			}
+			lastErr = err
+			continue
+		}
+		return keys, nil
+	}
+	return nil, fmt.Errorf("gave up after 2 attempts: %w", lastErr)
+}
+
+// maxBackoffShift caps the default backoff at 10ms << 10 (about 10s) so that it does not overflow.
+const maxBackoffShift = 10
+
+func defaultBackoff(attempt int) time.Duration {
+	return 10 * time.Millisecond << min(attempt-1, maxBackoffShift)
+}
+
+type retry struct {
+	maxAttempts int
+	backoff     func(attempt int) time.Duration
+}
+
+type retryOption func(*retry)
+
+// RetryOption is a option for configuring RetryBetween.
+type RetryOption retryOption
+
+// WithMaxAttempts returns a RetryOption that sets the max number of attempts including the first one.
+// The default is 3. RetryBetween returns an error if n is less than 1.
+func WithMaxAttempts(n int) RetryOption {
+	return func(r *retry) {
+		r.maxAttempts = n
+	}
+}
+
+// WithBackoff returns a RetryOption that sets the wait before each retry.
+// attempt is the number of failed attempts so far. The default doubles from 10ms up to about 10s.
+func WithBackoff(f func(attempt int) time.Duration) RetryOption {
+	return func(r *retry) {
+		r.backoff = f
+	}
+}
@@ -0,0 +1,167 @@
+package lexorank
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestGenerator_RetryBetween(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+	g := NewGenerator(WithCharacterSet(charSet))
+	noBackoff := WithBackoff(func(int) time.Duration { return 0 })
+
+	t.Run("retry on conflict", func(t *testing.T) {
+		saved := []Key{"1", "5"}
+		attempts := 0
+		key, err := g.RetryBetween(context.Background(), func(context.Context) (Key, Key, error) {
+			return saved[0], saved[len(saved)-1], nil
+		}, func(_ context.Context, key Key) error {
+			attempts++
+			if attempts == 1 {
+				// Another writer saved the same key, and it is now the next neighbor.
+				saved = []Key{"1", key}
+				return fmt.Errorf("duplicate: %w", ErrConflict)
+			}
+			return nil
+		}, noBackoff)
+		noError(t, err)
+		equalKey(t, key, "2")
+		if attempts != 2 {
+			t.Fatalf("expected 2 attempts, got %d", attempts)
+		}
+	})
+
+	t.Run("give up", func(t *testing.T) {
+		attempts := 0
+		_, err := g.RetryBetween(context.Background(), func(context.Context) (Key, Key, error) {
+			return "1", "5", nil
+		}, func(context.Context, Key) error {
+			attempts++
+			return ErrConflict
+		}, noBackoff, WithMaxAttempts(5))
+		if !errors.Is(err, ErrConflict) || attempts != 5 {
+			t.Fatalf("expected ErrConflict after 5 attempts, got %v after %d", err, attempts)
+		}
+	})
+
+	t.Run("no retry on other errors", func(t *testing.T) {
+		errSave := errors.New("save error")
+		attempts := 0
+		_, err := g.RetryBetween(context.Background(), func(context.Context) (Key, Key, error) {
+			return "1", "5", nil
+		}, func(context.Context, Key) error {
+			attempts++
+			return errSave
+		}, noBackoff)
+		if !errors.Is(err, errSave) || attempts != 1 {
+			t.Fatalf("expected save error after 1 attempt, got %v after %d", err, attempts)
+		}
+	})
+
+	t.Run("canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		_, err := g.RetryBetween(ctx, func(context.Context) (Key, Key, error) {
+			return "1", "5", nil
+		}, func(context.Context, Key) error {
+			cancel()
+			return ErrConflict
+		}, WithBackoff(func(int) time.Duration { return time.Hour }))
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("expected context.Canceled, got %v", err)
+		}
+	})
+	t.Run("error on invalid max attempts", func(t *testing.T) {
+		for _, n := range []int{0, -1} {
+			called := false
+			_, err := g.RetryBetween(context.Background(), func(context.Context) (Key, Key, error) {
+				called = true
+				return "1", "5", nil
+			}, func(context.Context, Key) error {
+				return nil
+			}, WithMaxAttempts(n))
+			if ErrorCode(err) != CodeInvalidArgument || called {
+				t.Fatalf("%d: expected CodeInvalidArgument without reading neighbors, got %v", n, err)
+			}
+		}
+	})
+
+	t.Run("default backoff", func(t *testing.T) {
+		for _, tt := range []struct {
+			attempt int
+			want    time.Duration
+		}{
+			{1, 10 * time.Millisecond},
+			{2, 20 * time.Millisecond},
+			{11, 10 * time.Millisecond << 10},
+			{100, 10 * time.Millisecond << 10},
+		} {
+			if got := defaultBackoff(tt.attempt); got != tt.want {
+				t.Fatalf("%d: expected %v, got %v", tt.attempt, tt.want, got)
+			}
+		}
+	})
+}
+
+func TestInsertBetween(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	t.Run("retry once on conflict", func(t *testing.T) {
+		next := Key("5")
+		attempts := 0
+		keys, err := InsertBetween(context.Background(), g, func(context.Context) (Key, Key, error) {
+			return "1", next, nil
+		}, []string{"a", "b"}, func(_ context.Context, items []string, keys []Key) error {
+			attempts++
+			if len(items) != len(keys) {
+				t.Fatalf("expected a key for each item, got %v for %v", keys, items)
+			}
+			if attempts == 1 {
+				// Another writer inserted a key, and it is now the next neighbor.
+				next = "3"
+				return fmt.Errorf("duplicate: %w", ErrConflict)
+			}
+			return nil
+		})
+		noError(t, err)
+		if want := []Key{"17", "23"}; !slices.Equal(keys, want) {
+			t.Fatalf("expected %v, got %v", want, keys)
+		}
+		if attempts != 2 {
+			t.Fatalf("expected 2 attempts, got %d", attempts)
+		}
+	})
+
+	t.Run("give up", func(t *testing.T) {
+		attempts := 0
+		_, err := InsertBetween(context.Background(), g, func(context.Context) (Key, Key, error) {
+			return "1", "5", nil
+		}, []int{1}, func(context.Context, []int, []Key) error {
+			attempts++
+			return ErrConflict
+		})
+		if !errors.Is(err, ErrConflict) || attempts != 2 {
+			t.Fatalf("expected ErrConflict after 2 attempts, got %v after %d", err, attempts)
+		}
+	})
+
+	t.Run("no retry on other errors", func(t *testing.T) {
+		errSave := errors.New("save failed")
+		attempts := 0
+		_, err := InsertBetween(context.Background(), g, func(context.Context) (Key, Key, error) {
+			return "1", "5", nil
+		}, []int{1}, func(context.Context, []int, []Key) error {
+			attempts++
+			return errSave
+		})
+		if !errors.Is(err, errSave) || attempts != 1 {
+			t.Fatalf("expected save error after 1 attempt, got %v after %d", err, attempts)
+		}
+	})
+}
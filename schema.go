@@ -0,0 +1,79 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// KeySchema returns a JSON Schema fragment for Key fields whose characters are from the character set.
+// If maxLength is positive, keys longer than maxLength are rejected by the schema.
+// The result can be embedded into JSON Schema or OpenAPI documents as is.
+func KeySchema(set CharacterSet, maxLength int) map[string]any {
+	schema := map[string]any{
+		"type":      "string",
+		"minLength": 1,
+		"pattern":   "^" + keyPattern(set, maxLength) + "$",
+	}
+	if maxLength > 0 {
+		schema["maxLength"] = maxLength
+	}
+	return schema
+}
+
+// BucketKeySchema returns a JSON Schema fragment for BucketKey fields of the bucket.
+// The label part must consist of characters of the character set as BucketKey.Validate requires, so it cannot
+// contain the separator. If maxLength is positive, keys whose rank part is longer than maxLength are rejected by
+// the schema.
+func BucketKeySchema(b *Bucket, maxLength int) map[string]any {
+	set := b.generator.characterSet
+	pattern := "^" + keyPattern(set, 0) + regexp.QuoteMeta(b.separator) + keyPattern(set, maxLength) + "$"
+	return map[string]any{
+		"type":    "string",
+		"pattern": pattern,
+	}
+}
+
+func keyPattern(set CharacterSet, maxLength int) string {
+	quantifier := "+"
+	if maxLength > 0 {
+		quantifier = fmt.Sprintf("{1,%d}", maxLength)
+	}
+	return "[" + regexpClass(characterSetRunes(set)) + "]" + quantifier
+}
+
+// regexpClass returns the content of a regular expression character class matching the sorted runes.
+// Runs of three or more consecutive runes are written as ranges.
+func regexpClass(runes []rune) string {
+	var sb strings.Builder
+	for i := 0; i < len(runes); {
+		j := i
+		for j+1 < len(runes) && runes[j+1] == runes[j]+1 {
+			j++
+		}
+		if j-i >= 2 {
+			sb.WriteString(quoteClassRune(runes[i]))
+			sb.WriteByte('-')
+			sb.WriteString(quoteClassRune(runes[j]))
+		} else {
+			for k := i; k <= j; k++ {
+				sb.WriteString(quoteClassRune(runes[k]))
+			}
+		}
+		i = j + 1
+	}
+	return sb.String()
+}
+
+func quoteClassRune(r rune) string {
+	switch r {
+	case '\\', ']', '[', '^', '-':
+		return `\` + string(r)
+	}
+	if r < ' ' || r == 0x7f {
+		return fmt.Sprintf(`\x%02x`, r)
+	}
+	return string(r)
+}
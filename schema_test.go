@@ -0,0 +1,72 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestKeySchema(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz-_^")
+	noError(t, err)
+
+	schema := KeySchema(charSet, 10)
+	want := map[string]any{
+		"type":      "string",
+		"minLength": 1,
+		"maxLength": 10,
+		"pattern":   `^[\-0-9A-Z\^_a-z]{1,10}$`,
+	}
+	if !reflect.DeepEqual(schema, want) {
+		t.Fatalf("expected %v, got %v", want, schema)
+	}
+
+	re := regexp.MustCompile(schema["pattern"].(string))
+	for key, want := range map[string]bool{
+		"0":           true,
+		"a-_^z":       true,
+		"":            false,
+		"a|b":         false,
+		"0123456789a": false,
+	} {
+		if got := re.MatchString(key); got != want {
+			t.Errorf("%q: expected %v, got %v", key, want, got)
+		}
+	}
+}
+
+func TestBucketKeySchema(t *testing.T) {
+	schema := BucketKeySchema(NewBucket(), 0)
+	re := regexp.MustCompile(schema["pattern"].(string))
+	for key, want := range map[string]bool{
+		"0|UUUUUU":  true,
+		"10|a":      true,
+		"|a":        false,
+		"0|":        false,
+		"0|a|b":     false,
+		"0|UUU-UUU": false,
+	} {
+		if got := re.MatchString(key); got != want {
+			t.Errorf("%q: expected %v, got %v", key, want, got)
+		}
+	}
+}
+
+func TestBucketKeySchema_MultiCharacterSeparator(t *testing.T) {
+	schema := BucketKeySchema(NewBucket(WithSeparatorString("::")), 0)
+	re := regexp.MustCompile(schema["pattern"].(string))
+	for key, want := range map[string]bool{
+		"0::UUUUUU": true,
+		"0:UUUUUU":  false,
+		"::a":       false,
+		"0::":       false,
+		"0::a::b":   false,
+		"0:a::b":    false,
+	} {
+		if got := re.MatchString(key); got != want {
+			t.Errorf("%q: expected %v, got %v", key, want, got)
+		}
+	}
+}
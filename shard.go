@@ -0,0 +1,118 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// Sharder derives shard prefixes for ranked data and composes sharded keys in the form "shard<separator>key",
+// so that the data can be spread across partitions while keys in each partition stay ordered.
+type Sharder struct {
+	shards    []string
+	bounds    []Key
+	separator string
+}
+
+// NewHashSharder creates a Sharder choosing a shard by the FNV-1a hash of the value given to Shard.
+func NewHashSharder(shards []string, separator string) (*Sharder, error) {
+	if err := validateShards(shards, separator); err != nil {
+		return nil, err
+	}
+	return &Sharder{shards, nil, separator}, nil
+}
+
+// NewRangeSharder creates a Sharder choosing a shard by the range of the key given to Shard.
+// bounds must be strictly increasing and have one less element than shards. A key less than bounds[0] belongs to
+// shards[0], and a key greater than or equal to bounds[i] and less than bounds[i+1] belongs to shards[i+1].
+func NewRangeSharder(shards []string, bounds []Key, separator string) (*Sharder, error) {
+	if err := validateShards(shards, separator); err != nil {
+		return nil, err
+	}
+	if len(bounds) != len(shards)-1 {
+		return nil, withCode(CodeInvalidArgument, fmt.Errorf("expected %d bounds for %d shards, got %d", len(shards)-1, len(shards), len(bounds)))
+	}
+	for i := 1; i < len(bounds); i++ {
+		if bounds[i-1] >= bounds[i] {
+			return nil, withCode(CodeInvalidArgument, fmt.Errorf("bounds must be strictly increasing: %q >= %q", bounds[i-1], bounds[i]))
+		}
+	}
+	return &Sharder{shards, bounds, separator}, nil
+}
+
+func validateShards(shards []string, separator string) error {
+	if len(shards) == 0 {
+		return withCode(CodeInvalidArgument, errors.New("shards must not be empty"))
+	}
+	if separator == "" {
+		return withCode(CodeInvalidArgument, errors.New("separator must not be empty"))
+	}
+	for _, s := range shards {
+		if s == "" || strings.Contains(s, separator) {
+			return withCode(CodeInvalidArgument, fmt.Errorf("invalid shard %q: it must be non-empty and must not contain the separator %q", s, separator))
+		}
+	}
+	return nil
+}
+
+// Shard returns the shard of v. v is an arbitrary identifier for the hash-based Sharder, and a Key for the
+// range-based Sharder. The result is deterministic.
+func (s *Sharder) Shard(v string) string {
+	if s.bounds == nil {
+		h := fnv.New32a()
+		h.Write([]byte(v))
+		return s.shards[h.Sum32()%uint32(len(s.shards))]
+	}
+	return s.shards[SliceIndex(s.bounds, Key(v))]
+}
+
+// Compose returns the sharded key of the key in the shard.
+func (s *Sharder) Compose(shard string, key Key) BucketKey {
+	return BucketKey(shard + s.separator + string(key))
+}
+
+// Split returns the shard and the key of the sharded key.
+func (s *Sharder) Split(key BucketKey) (string, Key, error) {
+	shard, k, ok := strings.Cut(string(key), s.separator)
+	if !ok || !slices.Contains(s.shards, shard) {
+		return "", "", withCode(CodeInvalidKey, fmt.Errorf("invalid sharded key %q: unknown shard", key))
+	}
+	return shard, Key(k), nil
+}
+
+// SliceKeyspace divides the keyspace of the Generator into n slices of equal size and returns the n-1 keys
+// bounding them, which are the shortest keys at the fractions i/n rounded down. The bounds can be given to
+// NewRangeSharder and SliceIndex, or used in range queries of each slice. The bounds start with the key prefix.
+func (g *Generator) SliceKeyspace(n int) ([]Key, error) {
+	if n <= 0 {
+		return nil, withCode(CodeInvalidArgument, fmt.Errorf("number of slices must be positive: %d", n))
+	}
+	d := newKeyDigits(g.characterSet)
+	length := 1
+	for size := new(big.Int).Set(d.base); size.Cmp(big.NewInt(int64(n))) < 0; size.Mul(size, d.base) {
+		length++
+	}
+	bounds := make([]Key, n-1)
+	for i := range bounds {
+		key, err := RatToKey(g.characterSet, big.NewRat(int64(i+1), int64(n)), length)
+		if err != nil {
+			return nil, err
+		}
+		bounds[i] = g.addPrefix(key)
+	}
+	return bounds, nil
+}
+
+// SliceIndex returns the index of the slice that the key belongs to, given the bounds of the slices returned by
+// SliceKeyspace. A key less than bounds[0] belongs to the slice 0, and a key greater than or equal to bounds[i]
+// and less than bounds[i+1] belongs to the slice i+1.
+func SliceIndex(bounds []Key, key Key) int {
+	i, found := slices.BinarySearch(bounds, key)
+	if found {
+		i++
+	}
+	return i
+}
@@ -0,0 +1,102 @@
+package lexorank
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestHashSharder(t *testing.T) {
+	s, err := NewHashSharder([]string{"a", "b", "c"}, ":")
+	noError(t, err)
+
+	shard := s.Shard("user-1")
+	if shard != s.Shard("user-1") {
+		t.Fatal("expected deterministic shard")
+	}
+
+	key := s.Compose(shard, "UUU")
+	gotShard, gotKey, err := s.Split(key)
+	noError(t, err)
+	equalKey(t, gotKey, "UUU")
+	if gotShard != shard {
+		t.Fatalf("expected %s, got %s", shard, gotShard)
+	}
+
+	if _, _, err := s.Split("d:UUU"); err == nil {
+		t.Fatal("expected error for unknown shard")
+	}
+	if _, err := NewHashSharder([]string{"a:b"}, ":"); err == nil {
+		t.Fatal("expected error for shard containing the separator")
+	}
+}
+
+func TestRangeSharder(t *testing.T) {
+	s, err := NewRangeSharder([]string{"s0", "s1", "s2"}, []Key{"H", "P"}, "|")
+	noError(t, err)
+
+	for key, want := range map[Key]string{
+		"0": "s0",
+		"G": "s0",
+		"H": "s1",
+		"O": "s1",
+		"P": "s2",
+		"z": "s2",
+	} {
+		if got := s.Shard(string(key)); got != want {
+			t.Errorf("%s: expected %s, got %s", key, want, got)
+		}
+	}
+
+	if _, err := NewRangeSharder([]string{"s0", "s1"}, []Key{"H", "P"}, "|"); err == nil {
+		t.Fatal("expected error for wrong number of bounds")
+	}
+	if _, err := NewRangeSharder([]string{"s0", "s1", "s2"}, []Key{"P", "H"}, "|"); err == nil {
+		t.Fatal("expected error for unsorted bounds")
+	}
+}
+
+func TestGenerator_SliceKeyspace(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	bounds, err := g.SliceKeyspace(4)
+	noError(t, err)
+	if !slices.Equal(bounds, []Key{"2", "5", "7"}) {
+		t.Fatalf("unexpected bounds: %v", bounds)
+	}
+
+	bounds, err = g.SliceKeyspace(12)
+	noError(t, err)
+	if len(bounds) != 11 || !slices.IsSorted(bounds) || len(slices.Compact(slices.Clone(bounds))) != 11 {
+		t.Fatalf("unexpected bounds: %v", bounds)
+	}
+
+	bounds, err = g.SliceKeyspace(1)
+	noError(t, err)
+	if len(bounds) != 0 {
+		t.Fatalf("unexpected bounds: %v", bounds)
+	}
+
+	if _, err := g.SliceKeyspace(0); err == nil {
+		t.Fatal("expected error for no slices")
+	}
+}
+
+func TestSliceIndex(t *testing.T) {
+	bounds := []Key{"2", "5", "7"}
+	for key, want := range map[Key]int{
+		"0":  0,
+		"19": 0,
+		"2":  1,
+		"49": 1,
+		"5":  2,
+		"7":  3,
+		"99": 3,
+	} {
+		if got := SliceIndex(bounds, key); got != want {
+			t.Errorf("%s: expected %d, got %d", key, want, got)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package lexorank
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidSignature is returned by Generator.Verify when the signature of a key does not match.
+var ErrInvalidSignature = withCode(CodeInvalidSignature, errors.New("invalid signature"))
+
+// WithHMAC returns a GeneratorOption that appends an HMAC-SHA256 of each generated key, encoded as n characters of
+// the character set, after a sub-separator. The sub-separator is the character right before the min character of
+// the set, which keeps signed keys in the same order as the keys without signatures.
+// Use Verify to reject keys that were not generated with the secret, such as keys computed by untrusted clients.
+// Signatures of the keys given to Between are stripped without verification.
+// Between and Verify return an error if n is not positive.
+func WithHMAC(secret []byte, n int) GeneratorOption {
+	return func(g *Generator) {
+		g.hmacSecret = secret
+		g.hmacLength = n
+	}
+}
+
+// Verify checks if the key has a valid signature appended by WithHMAC.
+// The key prefix and the KeyTransformer of the Generator are undone before the signature is checked.
+func (g *Generator) Verify(key Key) error {
+	if g.hmacSecret == nil {
+		return withCode(CodeInvalidArgument, errors.New("no HMAC secret is configured"))
+	}
+	sep, err := g.subSeparator()
+	if err != nil {
+		return err
+	}
+	if err := g.validateHMACLength(); err != nil {
+		return err
+	}
+	if g.keyPrefix != "" {
+		stripped, ok := strings.CutPrefix(string(key), g.keyPrefix)
+		if !ok {
+			return fmt.Errorf("%w: %q has no prefix %q", ErrInvalidSignature, key, g.keyPrefix)
+		}
+		key = Key(stripped)
+	}
+	if g.transformer != nil {
+		if key, err = g.transformer.Decode(key); err != nil {
+			return err
+		}
+	}
+	i := strings.LastIndex(string(key), sep)
+	if i < 0 {
+		return fmt.Errorf("%w: %q has no signature", ErrInvalidSignature, key)
+	}
+	if !hmac.Equal([]byte(key[i+len(sep):]), []byte(g.signature(key[:i]))) {
+		return fmt.Errorf("%w: %q", ErrInvalidSignature, key)
+	}
+	return nil
+}
+
+func (g *Generator) validateHMACLength() error {
+	if g.hmacLength <= 0 {
+		return withCode(CodeInvalidArgument, fmt.Errorf("HMAC length must be positive: %d", g.hmacLength))
+	}
+	return nil
+}
+
+func (g *Generator) betweenSigned(prevKey, nextKey Key) (Key, error) {
+	sep, err := g.subSeparator()
+	if err != nil {
+		return "", err
+	}
+	if err := g.validateHMACLength(); err != nil {
+		return "", err
+	}
+
+	signed := *g
+	signed.hmacSecret = nil
+	key, err := signed.generate(unsign(prevKey, sep), unsign(nextKey, sep))
+	if key == "" {
+		return "", err
+	}
+	return key + Key(sep) + g.signature(key), err
+}
+
+// signKey appends the signature to the key generated outside of Between. The key is kept as is without WithHMAC.
+func (g *Generator) signKey(key Key) (Key, error) {
+	if g.hmacSecret == nil || key == "" {
+		return key, nil
+	}
+	sep, err := g.subSeparator()
+	if err != nil {
+		return "", err
+	}
+	if err := g.validateHMACLength(); err != nil {
+		return "", err
+	}
+	return key + Key(sep) + g.signature(key), nil
+}
+
+// unsignKey strips the signature from the key given outside of Between without verification.
+func (g *Generator) unsignKey(key Key) Key {
+	if g.hmacSecret == nil {
+		return key
+	}
+	sep, err := g.subSeparator()
+	if err != nil {
+		return key
+	}
+	return unsign(key, sep)
+}
+
+func unsign(key Key, sep string) Key {
+	if i := strings.LastIndex(string(key), sep); i >= 0 {
+		return key[:i]
+	}
+	return key
+}
+
+func (g *Generator) signature(key Key) Key {
+	mac := hmac.New(sha256.New, g.hmacSecret)
+	mac.Write([]byte(key))
+	sum := mac.Sum(nil)
+	runes := characterSetRunes(g.characterSet)
+	sig := make([]rune, g.hmacLength)
+	for i := range sig {
+		sig[i] = runes[int(sum[i%len(sum)])%len(runes)]
+	}
+	return Key(sig)
+}
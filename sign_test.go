@@ -0,0 +1,125 @@
+package lexorank
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestWithHMAC(t *testing.T) {
+	g := NewGenerator(WithHMAC([]byte("secret"), 8))
+
+	key, err := g.Initial()
+	noError(t, err)
+	if !strings.HasPrefix(string(key), "UUUUUU/") || len(key) != 15 {
+		t.Fatalf("unexpected key: %s", key)
+	}
+	noError(t, g.Verify(key))
+
+	next, err := g.Next(key)
+	noError(t, err)
+	if !strings.HasPrefix(string(next), "UUUUUV/") {
+		t.Fatalf("unexpected key: %s", next)
+	}
+	noError(t, g.Verify(next))
+	validateKey(t, next, key, "")
+
+	between, err := g.Between(key, next)
+	noError(t, err)
+	noError(t, g.Verify(between))
+	validateKey(t, between, key, next)
+
+	for _, key := range []Key{"UUUUUU", "UUUUUV" + key[6:], key[:len(key)-1] + "0"} {
+		if err := g.Verify(key); !errors.Is(err, ErrInvalidSignature) {
+			t.Fatalf("%s: expected ErrInvalidSignature, got %v", key, err)
+		}
+	}
+	if err := NewGenerator(WithHMAC([]byte("other"), 8)).Verify(key); !errors.Is(err, ErrInvalidSignature) {
+		t.Fatalf("expected ErrInvalidSignature, got %v", err)
+	}
+}
+
+func TestWithHMAC_InvalidLength(t *testing.T) {
+	for _, n := range []int{0, -1} {
+		g := NewGenerator(WithHMAC([]byte("secret"), n))
+		if _, err := g.Initial(); ErrorCode(err) != CodeInvalidArgument {
+			t.Fatalf("%d: expected %s, got %v", n, CodeInvalidArgument, err)
+		}
+		if err := g.Verify("anything/"); ErrorCode(err) != CodeInvalidArgument {
+			t.Fatalf("%d: expected %s, got %v", n, CodeInvalidArgument, err)
+		}
+	}
+}
+
+func TestGenerator_Verify_PrefixAndTransformer(t *testing.T) {
+	for name, g := range map[string]*Generator{
+		"prefix":      NewGenerator(WithHMAC([]byte("secret"), 6), WithKeyPrefix("rnk_")),
+		"transformer": NewGenerator(WithHMAC([]byte("secret"), 6), WithTransformer(prefixTransformer("x"))),
+	} {
+		t.Run(name, func(t *testing.T) {
+			key, err := g.Initial()
+			noError(t, err)
+			noError(t, g.Verify(key))
+
+			next, err := g.Next(key)
+			noError(t, err)
+			noError(t, g.Verify(next))
+
+			if err := g.Verify(key[:len(key)-1] + "0"); !errors.Is(err, ErrInvalidSignature) {
+				t.Fatalf("expected ErrInvalidSignature, got %v", err)
+			}
+		})
+	}
+}
+
+func TestWithHMAC_EntryPoints(t *testing.T) {
+	g := NewGenerator(WithHMAC([]byte("secret"), 6), WithKeyPrefix("rnk_"))
+	verify := func(t *testing.T, keys ...Key) {
+		t.Helper()
+		for _, key := range keys {
+			noError(t, g.Verify(key))
+		}
+	}
+
+	prev, err := g.Initial()
+	noError(t, err)
+	next, err := g.Next(prev)
+	noError(t, err)
+
+	key, err := g.BetweenWithLength(prev, next, 8)
+	noError(t, err)
+	verify(t, key)
+	validateKey(t, key, prev, next)
+
+	long, err := g.Between(prev, key)
+	noError(t, err)
+	compacted, err := g.Compact(long, prev, key)
+	noError(t, err)
+	verify(t, compacted)
+
+	keys, err := AssignRanks(g, []int{1, 2, 3})
+	noError(t, err)
+	verify(t, keys...)
+	verify(t, slices.Collect(g.InitialSeq(3))...)
+
+	r, err := NewRebalancer(g)
+	noError(t, err)
+	for _, key := range r.Rebalance(slices.Values([]Key{prev, next})) {
+		verify(t, key)
+	}
+	noError(t, r.Err())
+	n := 0
+	for _, key := range r.RebalanceLong(slices.Values([]Key{prev, long, next}), 6) {
+		verify(t, key)
+		n++
+	}
+	noError(t, r.Err())
+	if n != 1 {
+		t.Fatalf("expected 1 rebalanced key, got %d", n)
+	}
+
+	if _, err := NewGenerator(WithHMAC([]byte("secret"), 0)).BetweenWithLength("", "", 3); ErrorCode(err) != CodeInvalidArgument {
+		t.Fatalf("expected %s, got %v", CodeInvalidArgument, err)
+	}
+}
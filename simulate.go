@@ -0,0 +1,110 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// Workload is a mix of operations simulated by Simulate. The weights are relative to their sum.
+type Workload struct {
+	// Operations is the number of operations to simulate.
+	Operations int
+	// Head is the weight of insertions before the first key.
+	Head float64
+	// Tail is the weight of insertions after the last key.
+	Tail float64
+	// Random is the weight of insertions at random positions.
+	Random float64
+	// SameGap is the weight of insertions right after the initial key.
+	SameGap float64
+	// Delete is the weight of deletions of random keys.
+	Delete float64
+}
+
+// SimulationReport is the result of Simulate.
+type SimulationReport struct {
+	// Lengths are the sorted lengths of the keys generated by the simulation.
+	Lengths []int
+	// Keys is the number of keys remaining after the simulation.
+	Keys int
+}
+
+// Percentile returns the key length at the percentile p in [0, 100] of the generated keys.
+func (r SimulationReport) Percentile(p float64) int {
+	if len(r.Lengths) == 0 {
+		return 0
+	}
+	i := int(math.Ceil(p/100*float64(len(r.Lengths)))) - 1
+	return r.Lengths[min(max(i, 0), len(r.Lengths)-1)]
+}
+
+// Simulate runs the workload starting from the initial key and reports the lengths of the generated keys,
+// so that character sets, algorithms and other options can be compared for a workload.
+// Random choices use the source of WithRandSource. If a key cannot be generated, the report of the operations
+// so far is returned with the error.
+func (g *Generator) Simulate(w Workload) (SimulationReport, error) {
+	weights := []float64{w.Head, w.Tail, w.Random, w.SameGap, w.Delete}
+	var total float64
+	for _, weight := range weights {
+		if weight < 0 {
+			return SimulationReport{}, withCode(CodeInvalidArgument, fmt.Errorf("weight must not be negative: %v", weight))
+		}
+		total += weight
+	}
+	if total == 0 {
+		return SimulationReport{}, withCode(CodeInvalidArgument, errors.New("at least one weight must be positive"))
+	}
+
+	initial, err := g.Initial()
+	if err != nil {
+		return SimulationReport{}, err
+	}
+	keys := []Key{initial}
+	var lengths []int
+	report := func() SimulationReport {
+		slices.Sort(lengths)
+		return SimulationReport{lengths, len(keys)}
+	}
+
+	for range w.Operations {
+		op, x := 0, g.rand.Float64()*total
+		for ; op < len(weights)-1 && x >= weights[op]; op++ {
+			x -= weights[op]
+		}
+		if op == 4 {
+			if len(keys) > 0 {
+				i := g.rand.IntN(len(keys))
+				keys = slices.Delete(keys, i, i+1)
+			}
+			continue
+		}
+		var i int
+		switch op {
+		case 1:
+			i = len(keys)
+		case 2:
+			i = g.rand.IntN(len(keys) + 1)
+		case 3:
+			i, _ = slices.BinarySearch(keys, initial)
+			if i < len(keys) && keys[i] == initial {
+				i++
+			}
+		}
+		var prev, next Key
+		if i > 0 {
+			prev = keys[i-1]
+		}
+		if i < len(keys) {
+			next = keys[i]
+		}
+		key, err := g.Between(prev, next)
+		if err != nil {
+			return report(), err
+		}
+		keys = slices.Insert(keys, i, key)
+		lengths = append(lengths, len([]rune(key)))
+	}
+	return report(), nil
+}
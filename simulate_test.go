@@ -0,0 +1,41 @@
+package lexorank
+
+import (
+	"math/rand/v2"
+	"slices"
+	"testing"
+)
+
+func TestGenerator_Simulate(t *testing.T) {
+	g := NewGenerator(WithRandSource(rand.NewPCG(1, 2)))
+
+	report, err := g.Simulate(Workload{Operations: 1000, Tail: 1})
+	noError(t, err)
+	if report.Keys != 1001 || len(report.Lengths) != 1000 {
+		t.Fatalf("unexpected report: %d keys, %d lengths", report.Keys, len(report.Lengths))
+	}
+	if report.Percentile(0) != 6 || report.Percentile(100) != 6 {
+		t.Fatalf("expected keys of 6 characters, got %d-%d", report.Percentile(0), report.Percentile(100))
+	}
+
+	report, err = g.Simulate(Workload{Operations: 1000, Random: 2, SameGap: 1, Delete: 1})
+	noError(t, err)
+	if !slices.IsSorted(report.Lengths) || report.Keys >= 1000 {
+		t.Fatalf("unexpected report: %d keys", report.Keys)
+	}
+	if p50, p99 := report.Percentile(50), report.Percentile(99); p50 > p99 || p99 <= 6 {
+		t.Fatalf("unexpected percentiles: p50=%d p99=%d", p50, p99)
+	}
+
+	mudder, err := NewGenerator(WithRandSource(rand.NewPCG(1, 2)), WithMudderSubdivision()).Simulate(Workload{Operations: 1000, SameGap: 1})
+	noError(t, err)
+	heuristic, err := NewGenerator(WithRandSource(rand.NewPCG(1, 2))).Simulate(Workload{Operations: 1000, SameGap: 1})
+	noError(t, err)
+	if mudder.Percentile(100) > heuristic.Percentile(100) {
+		t.Fatalf("expected mudder keys to be no longer: %d > %d", mudder.Percentile(100), heuristic.Percentile(100))
+	}
+
+	if _, err := g.Simulate(Workload{Operations: 1}); err == nil {
+		t.Fatal("expected error without weights")
+	}
+}
@@ -0,0 +1,55 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"database/sql/driver"
+	"fmt"
+)
+
+// Value implements driver.Valuer.
+func (k Key) Value() (driver.Value, error) {
+	return string(k), nil
+}
+
+// Scan implements sql.Scanner.
+func (k *Key) Scan(src any) error {
+	s, err := scanString(src, "Key")
+	if err != nil {
+		return err
+	}
+	*k = Key(s)
+	return nil
+}
+
+// Value implements driver.Valuer.
+// It returns a *BucketKeyError if the key is not in the format "bucket|key".
+func (k BucketKey) Value() (driver.Value, error) {
+	if err := k.validateFormat(); err != nil {
+		return nil, err
+	}
+	return string(k), nil
+}
+
+// Scan implements sql.Scanner.
+// It returns a *BucketKeyError if the key is not in the format "bucket|key".
+func (k *BucketKey) Scan(src any) error {
+	s, err := scanString(src, "BucketKey")
+	if err != nil {
+		return err
+	}
+	return k.set(s)
+}
+
+func scanString(src any, name string) (string, error) {
+	switch v := src.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	default:
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("cannot scan %T into %s", src, name))
+	}
+}
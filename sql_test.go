@@ -0,0 +1,96 @@
+//go:build !tinygo && !lexorank_minimal
+
+package lexorank
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestKey_Encoding(t *testing.T) {
+	var k Key
+	noError(t, k.Scan([]byte("abc")))
+	equalKey(t, k, "abc")
+	noError(t, k.Scan(nil))
+	equalKey(t, k, "")
+	if err := k.Scan(1); err == nil {
+		t.Fatal("expected error")
+	}
+
+	v, err := Key("abc").Value()
+	noError(t, err)
+	if v != "abc" {
+		t.Fatalf("unexpected value: %v", v)
+	}
+
+	b, err := json.Marshal(map[string]Key{"key": "abc"})
+	noError(t, err)
+	if string(b) != `{"key":"abc"}` {
+		t.Fatalf("unexpected json: %s", b)
+	}
+	noError(t, json.Unmarshal([]byte(`"xyz"`), &k))
+	equalKey(t, k, "xyz")
+}
+
+func TestBucketKey_Encoding(t *testing.T) {
+	var k BucketKey
+	noError(t, k.Scan("0|abc"))
+	equalBucketKey(t, k, "0|abc")
+
+	v, err := k.Value()
+	noError(t, err)
+	if v != "0|abc" {
+		t.Fatalf("unexpected value: %v", v)
+	}
+
+	b, err := json.Marshal(k)
+	noError(t, err)
+	if string(b) != `"0|abc"` {
+		t.Fatalf("unexpected json: %s", b)
+	}
+	noError(t, json.Unmarshal([]byte(`"1|xyz"`), &k))
+	equalBucketKey(t, k, "1|xyz")
+
+	// Keys with a separator other than the default are encoded as well.
+	bucket := NewBucket(WithSeparator(':'))
+	key, err := bucket.Initial()
+	noError(t, err)
+	b, err = json.Marshal(key)
+	noError(t, err)
+	noError(t, json.Unmarshal(b, &k))
+	equalBucketKey(t, k, key)
+	noError(t, k.Validate(bucket))
+	v, err = key.Value()
+	noError(t, err)
+	noError(t, k.Scan(v))
+	equalBucketKey(t, k, key)
+	noError(t, json.Unmarshal([]byte(`"0::abc"`), &k))
+	equalBucketKey(t, k, "0::abc")
+
+	for _, tt := range []struct {
+		key  string
+		part BucketKeyPart
+	}{
+		{"abc", BucketKeySeparator},
+		{"|abc", BucketKeyLabel},
+		{"0|", BucketKeyRank},
+		{"0::", BucketKeyRank},
+	} {
+		var keyErr *BucketKeyError
+		err := json.Unmarshal([]byte(`"`+tt.key+`"`), &k)
+		if !errors.As(err, &keyErr) || keyErr.Part != tt.part {
+			t.Fatalf("%s: expected error about %s, got %v", tt.key, tt.part, err)
+		}
+		if err := k.Scan(tt.key); !errors.As(err, &keyErr) || keyErr.Part != tt.part {
+			t.Fatalf("%s: expected error about %s, got %v", tt.key, tt.part, err)
+		}
+		if _, err := BucketKey(tt.key).Value(); !errors.As(err, &keyErr) || keyErr.Part != tt.part {
+			t.Fatalf("%s: expected error about %s, got %v", tt.key, tt.part, err)
+		}
+		if _, err := json.Marshal(BucketKey(tt.key)); !errors.As(err, &keyErr) || keyErr.Part != tt.part {
+			t.Fatalf("%s: expected error about %s, got %v", tt.key, tt.part, err)
+		}
+	}
+	equalBucketKey(t, k, "0::abc")
+}
@@ -0,0 +1,45 @@
+package lexorank
+
+import "fmt"
+
+// SQLiteFuncRegisterer is a connection that can register application-defined SQL functions.
+// *sqlite3.SQLiteConn of github.com/mattn/go-sqlite3 satisfies it.
+type SQLiteFuncRegisterer interface {
+	RegisterFunc(name string, impl any, pure bool) error
+}
+
+// RegisterSQLiteFunc registers lexorank_between(prev, next) on the connection.
+// The function returns the same key as g.Between, and NULL arguments are treated as empty keys.
+// Use it in the ConnectHook of the driver, so that every connection has the function.
+func RegisterSQLiteFunc(conn SQLiteFuncRegisterer, g *Generator) error {
+	return conn.RegisterFunc("lexorank_between", SQLiteBetweenFunc(g), true)
+}
+
+// SQLiteBetweenFunc returns the implementation of lexorank_between for drivers that take a plain Go function.
+func SQLiteBetweenFunc(g *Generator) func(prev, next any) (string, error) {
+	return func(prev, next any) (string, error) {
+		prevKey, err := sqliteKeyArg(prev)
+		if err != nil {
+			return "", err
+		}
+		nextKey, err := sqliteKeyArg(next)
+		if err != nil {
+			return "", err
+		}
+		key, err := g.Between(prevKey, nextKey)
+		return string(key), err
+	}
+}
+
+func sqliteKeyArg(v any) (Key, error) {
+	switch v := v.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return Key(v), nil
+	case []byte:
+		return Key(v), nil
+	default:
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("lexorank_between: unsupported argument type %T", v))
+	}
+}
@@ -0,0 +1,41 @@
+package lexorank
+
+import "testing"
+
+type fakeSQLiteConn map[string]any
+
+func (c fakeSQLiteConn) RegisterFunc(name string, impl any, _ bool) error {
+	c[name] = impl
+	return nil
+}
+
+func TestRegisterSQLiteFunc(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("555"))
+
+	conn := fakeSQLiteConn{}
+	noError(t, RegisterSQLiteFunc(conn, g))
+	between := conn["lexorank_between"].(func(prev, next any) (string, error))
+
+	for _, tt := range []struct {
+		prev any
+		next any
+		want string
+	}{
+		{nil, nil, "555"},
+		{"555", nil, "556"},
+		{nil, []byte("555"), "554"},
+		{"699", "700", "6994"},
+	} {
+		got, err := between(tt.prev, tt.next)
+		noError(t, err)
+		if got != tt.want {
+			t.Fatalf("expected %s, got %s", tt.want, got)
+		}
+	}
+
+	if _, err := between(1, nil); err == nil {
+		t.Fatal("expected error, but got nil")
+	}
+}
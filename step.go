@@ -0,0 +1,32 @@
+package lexorank
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// Add returns the key steps discrete steps after key, where a step is the smallest difference between keys of
+// precision characters. A negative steps returns a key before key. Keys shorter than precision are treated as
+// padded with the min character. The key prefix and the signature of WithHMAC are not counted in the precision.
+//
+// For example, with the character set "0123456789", Add("5", 3, 2) is "53" and Add("5", -1, 2) is "49".
+// It is useful to reserve a block of positions or to partition the keyspace among writers.
+func (g *Generator) Add(key Key, steps, precision int) (Key, error) {
+	raw, err := g.rawKey(key)
+	if err != nil {
+		return "", err
+	}
+	if len([]rune(raw)) > precision {
+		return "", withCode(CodeInvalidKey, fmt.Errorf("key (%q) is longer than precision %d", key, precision))
+	}
+	d := newKeyDigits(g.characterSet)
+	v, err := d.toInt(raw, precision)
+	if err != nil {
+		return "", err
+	}
+	v.Add(v, big.NewInt(int64(steps)))
+	if v.Sign() <= 0 || v.Cmp(d.max(precision)) > 0 {
+		return "", withCode(CodeKeySpaceExhausted, fmt.Errorf("key (%q) %+d steps is out of the keyspace of precision %d", key, steps, precision))
+	}
+	return g.finishKey(d.fromInt(v, precision))
+}
@@ -0,0 +1,50 @@
+package lexorank
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestGenerator_Add(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet))
+
+	for _, tt := range []struct {
+		key       Key
+		steps     int
+		precision int
+		want      Key
+	}{
+		{"5", 3, 2, "53"},
+		{"5", -1, 2, "49"},
+		{"5", 0, 1, "5"},
+		{"599", 1, 3, "600"},
+		{"01", -1, 3, "009"},
+		{"9", 10, 3, "910"},
+	} {
+		t.Run(fmt.Sprintf("%s_%d_%d", tt.key, tt.steps, tt.precision), func(t *testing.T) {
+			key, err := g.Add(tt.key, tt.steps, tt.precision)
+			noError(t, err)
+			equalKey(t, key, tt.want)
+		})
+	}
+
+	for _, tt := range []struct {
+		key       Key
+		steps     int
+		precision int
+	}{
+		{"55", 1, 1},
+		{"9", 1, 1},
+		{"1", -1, 1},
+		{"a", 1, 1},
+	} {
+		t.Run(fmt.Sprintf("error_%s_%d_%d", tt.key, tt.steps, tt.precision), func(t *testing.T) {
+			if _, err := g.Add(tt.key, tt.steps, tt.precision); err == nil {
+				t.Fatal("expected error, but got nil")
+			}
+		})
+	}
+}
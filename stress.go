@@ -0,0 +1,126 @@
+package lexorank
+
+import (
+	"fmt"
+	"slices"
+)
+
+// InsertionPattern is a pattern of repeated insertions simulated by Stress.
+type InsertionPattern int
+
+const (
+	// InsertAtHead inserts every key before the first key.
+	InsertAtHead InsertionPattern = iota
+	// InsertAtTail inserts every key after the last key.
+	InsertAtTail
+	// InsertInSameGap inserts every key right after the initial key, splitting the same gap repeatedly.
+	InsertInSameGap
+	// InsertAtRandom inserts every key at a random position chosen with the source of WithRandSource.
+	InsertAtRandom
+)
+
+func (p InsertionPattern) String() string {
+	switch p {
+	case InsertAtHead:
+		return "head"
+	case InsertAtTail:
+		return "tail"
+	case InsertInSameGap:
+		return "same-gap"
+	case InsertAtRandom:
+		return "random"
+	default:
+		return fmt.Sprintf("InsertionPattern(%d)", int(p))
+	}
+}
+
+// StressReport is the result of Stress.
+type StressReport struct {
+	Pattern InsertionPattern
+	// Lengths are the lengths of the inserted keys in the order of insertions.
+	Lengths []int
+}
+
+// MaxLength returns the length of the longest inserted key.
+func (r StressReport) MaxLength() int {
+	if len(r.Lengths) == 0 {
+		return 0
+	}
+	return slices.Max(r.Lengths)
+}
+
+// Stress simulates n insertions of the pattern starting from the initial key, and reports the lengths of the
+// generated keys. It is useful to compare character sets and strategies for the worst-case access pattern.
+// If a key cannot be generated, the report of the insertions so far is returned with the error.
+func (g *Generator) Stress(pattern InsertionPattern, n int) (StressReport, error) {
+	report := StressReport{pattern, make([]int, 0, n)}
+	first, err := g.Initial()
+	if err != nil {
+		return report, err
+	}
+	last, gap, keys := first, Key(""), []Key{first}
+	if pattern == InsertInSameGap {
+		if gap, err = g.Next(first); err != nil {
+			return report, err
+		}
+	}
+	for range n {
+		var key Key
+		switch pattern {
+		case InsertAtHead:
+			key, err = g.Prev(first)
+			first = key
+		case InsertAtTail:
+			key, err = g.Next(last)
+			last = key
+		case InsertInSameGap:
+			key, err = g.Between(first, gap)
+			gap = key
+		case InsertAtRandom:
+			i := g.rand.IntN(len(keys) + 1)
+			var prev, next Key
+			if i > 0 {
+				prev = keys[i-1]
+			}
+			if i < len(keys) {
+				next = keys[i]
+			}
+			if key, err = g.Between(prev, next); err == nil {
+				keys = slices.Insert(keys, i, key)
+			}
+		default:
+			return report, withCode(CodeInvalidArgument, fmt.Errorf("unknown insertion pattern: %v", pattern))
+		}
+		if err != nil {
+			return report, err
+		}
+		report.Lengths = append(report.Lengths, len([]rune(key)))
+	}
+	return report, nil
+}
+
+// StressScenario is a named Generator to be compared by RunStress.
+type StressScenario struct {
+	Name      string
+	Generator *Generator
+}
+
+// StressResult is the result of a StressScenario for an InsertionPattern.
+type StressResult struct {
+	Scenario string
+	Report   StressReport
+	// Err is the error stopping the insertions, if any.
+	Err error
+}
+
+// RunStress runs Stress of every pattern with n insertions for every scenario.
+func RunStress(scenarios []StressScenario, patterns []InsertionPattern, n int) []StressResult {
+	results := make([]StressResult, 0, len(scenarios)*len(patterns))
+	for _, s := range scenarios {
+		for _, p := range patterns {
+			report, err := s.Generator.Stress(p, n)
+			results = append(results, StressResult{s.Name, report, err})
+		}
+	}
+	return results
+}
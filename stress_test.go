@@ -0,0 +1,44 @@
+package lexorank
+
+import (
+	"math/rand/v2"
+	"testing"
+)
+
+func TestGenerator_Stress(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithRandSource(rand.NewPCG(1, 2)))
+
+	for _, tt := range []struct {
+		pattern   InsertionPattern
+		maxLength int
+		err       bool
+	}{
+		{InsertAtTail, 2, false},
+		{InsertInSameGap, 5, false},
+		{InsertAtHead, 1, true},
+		{InsertAtRandom, 2, false},
+	} {
+		t.Run(tt.pattern.String(), func(t *testing.T) {
+			report, err := g.Stress(tt.pattern, 10)
+			if (err != nil) != tt.err {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if report.MaxLength() != tt.maxLength {
+				t.Fatalf("expected max length %d, got %d: %v", tt.maxLength, report.MaxLength(), report.Lengths)
+			}
+		})
+	}
+
+	results := RunStress([]StressScenario{{"digits", g}, {"default", NewGenerator()}}, []InsertionPattern{InsertAtTail, InsertInSameGap}, 10)
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	for _, r := range results {
+		if r.Err != nil || len(r.Report.Lengths) != 10 {
+			t.Fatalf("unexpected result: %+v", r)
+		}
+	}
+}
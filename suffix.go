@@ -0,0 +1,58 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// WithUniqueSuffix returns a GeneratorOption that appends n random characters of the character set to every
+// generated key, after a sub-separator, so that writers computing the same key at the same time still generate
+// distinct keys. The sub-separator is the character right before the min character of the set, e.g. '/' for
+// DefaultCharacterSet, which keeps keys with suffixes in the same order as the keys without them.
+// The suffixes of prevKey and nextKey given to Between are ignored unless the keys differ only by their suffixes.
+func WithUniqueSuffix(n int) GeneratorOption {
+	return func(g *Generator) {
+		g.suffixLength = n
+	}
+}
+
+func (g *Generator) betweenWithSuffix(prevKey, nextKey Key) (Key, error) {
+	sep, err := g.subSeparator()
+	if err != nil {
+		return "", err
+	}
+	prevBase, prevSuffix, _ := strings.Cut(string(prevKey), sep)
+	nextBase, nextSuffix, _ := strings.Cut(string(nextKey), sep)
+
+	if prevBase != "" && prevBase == nextBase {
+		// The keys were generated from the same key. Generate a suffix between their suffixes.
+		if prevSuffix >= nextSuffix {
+			return "", withCode(CodeOrderViolation, fmt.Errorf("prevKey (%q) must be strictly less than nextKey (%q)", prevKey, nextKey))
+		}
+		suffix, err := g.between(Key(prevSuffix), Key(nextSuffix))
+		if err != nil {
+			return "", err
+		}
+		return Key(prevBase+sep) + suffix, nil
+	}
+
+	key, err := g.betweenPadded(Key(prevBase), Key(nextBase))
+	if err != nil && !errors.Is(err, ErrKeySpaceDense) {
+		return "", err
+	}
+	runes := characterSetRunes(g.characterSet)
+	suffix := make([]rune, g.suffixLength)
+	for i := range suffix {
+		suffix[i] = runes[g.rand.IntN(len(runes))]
+	}
+	return key + Key(sep) + Key(suffix), err
+}
+
+// subSeparator returns the separator of the parts appended to keys, which sorts before any character of the set.
+func (g *Generator) subSeparator() (string, error) {
+	if g.characterSet.Min() == 0 {
+		return "", withCode(CodeInvalidCharacterSet, errors.New("sub-separator is not available for a character set containing the character 0"))
+	}
+	return string(g.characterSet.Min() - 1), nil
+}
@@ -0,0 +1,50 @@
+package lexorank
+
+import (
+	"math/rand/v2"
+	"strings"
+	"testing"
+)
+
+func TestWithUniqueSuffix(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithUniqueSuffix(3), WithRandSource(rand.NewPCG(1, 2)))
+
+	key, err := g.Initial()
+	noError(t, err)
+	if !strings.HasPrefix(string(key), "5/") || len(key) != 5 {
+		t.Fatalf("expected 5/ with 3 suffix characters, got %s", key)
+	}
+
+	key2, err := g.Initial()
+	noError(t, err)
+	if key == key2 {
+		t.Fatalf("expected unique keys, got %s twice", key)
+	}
+
+	for _, tt := range []struct {
+		prev Key
+		next Key
+		base string
+	}{
+		{"5/123", "", "6/"},
+		{"", "5/123", "4/"},
+		{"5/999", "6/000", "54/"},
+		{"5/999", "55/000", "52/"},
+		{"5/123", "5/125", "5/124"},
+	} {
+		key, err := g.Between(tt.prev, tt.next)
+		noError(t, err)
+		if !strings.HasPrefix(string(key), tt.base) {
+			t.Fatalf("%s-%s: expected prefix %s, got %s", tt.prev, tt.next, tt.base, key)
+		}
+		validateKey(t, key, tt.prev, tt.next)
+	}
+
+	t.Run("recursive", func(t *testing.T) {
+		g := NewGenerator(WithUniqueSuffix(2), WithRandSource(rand.NewPCG(1, 2)))
+		testRecursive(t, g, "", "", 12)
+	})
+}
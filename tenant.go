@@ -0,0 +1,100 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// TenantKey represents a BucketKey of a tenant in the format "tenant|bucket|key".
+type TenantKey string
+
+// String returns a string representation of the TenantKey.
+func (k TenantKey) String() string {
+	return string(k)
+}
+
+// ErrTenantMismatch is returned when the keys given to TenantBucket.Between belong to different tenants.
+var ErrTenantMismatch = withCode(CodeTenantMismatch, errors.New("tenant mismatch"))
+
+// TenantBucket generates keys of a Bucket isolated by tenants.
+// The tenant is placed ahead of the bucket with the separator of the Bucket.
+type TenantBucket struct {
+	bucket *Bucket
+}
+
+// NewTenantBucket creates a new TenantBucket. If b is nil, NewBucket() is used.
+func NewTenantBucket(b *Bucket) *TenantBucket {
+	if b == nil {
+		b = NewBucket()
+	}
+	return &TenantBucket{b}
+}
+
+// Between generates a key that comes between the prev and next keys of the same tenant.
+// At least one of prev and next must be given. Use InitialIn to generate the first key of a tenant.
+func (t *TenantBucket) Between(prev, next TenantKey) (TenantKey, error) {
+	if prev == "" && next == "" {
+		return "", withCode(CodeInvalidArgument, errors.New("prev or next key is required to determine the tenant"))
+	}
+	var tenant string
+	var prevKey, nextKey BucketKey
+	if prev != "" {
+		tn, key, err := t.Split(prev)
+		if err != nil {
+			return "", err
+		}
+		tenant, prevKey = tn, key
+	}
+	if next != "" {
+		tn, key, err := t.Split(next)
+		if err != nil {
+			return "", err
+		}
+		if tenant != "" && tenant != tn {
+			return "", fmt.Errorf("%w: %q != %q", ErrTenantMismatch, tenant, tn)
+		}
+		tenant, nextKey = tn, key
+	}
+	k, err := t.bucket.Between(prevKey, nextKey)
+	if k == "" {
+		return "", err
+	}
+	return t.compose(tenant, k), err
+}
+
+// Next generates a key that comes after the given key.
+func (t *TenantBucket) Next(key TenantKey) (TenantKey, error) {
+	return t.Between(key, "")
+}
+
+// Prev generates a key that comes before the given key.
+func (t *TenantBucket) Prev(key TenantKey) (TenantKey, error) {
+	return t.Between("", key)
+}
+
+// InitialIn generates the initial key of the tenant in the specified bucket.
+// If bucket is empty, the default prefix of the Bucket is used.
+func (t *TenantBucket) InitialIn(tenant, bucket string) (TenantKey, error) {
+	if tenant == "" || strings.Contains(tenant, t.bucket.separator) {
+		return "", withCode(CodeInvalidArgument, fmt.Errorf("invalid tenant %q: it must be non-empty and must not contain the separator %q", tenant, t.bucket.separator))
+	}
+	k, err := t.bucket.InitialIn(bucket)
+	if err != nil {
+		return "", err
+	}
+	return t.compose(tenant, k), nil
+}
+
+// Split returns the tenant and the BucketKey of the key.
+func (t *TenantBucket) Split(key TenantKey) (string, BucketKey, error) {
+	tenant, k, ok := strings.Cut(string(key), t.bucket.separator)
+	if !ok || tenant == "" {
+		return "", "", withCode(CodeInvalidKey, fmt.Errorf("key %q is not in format of tenant key", key))
+	}
+	return tenant, BucketKey(k), nil
+}
+
+func (t *TenantBucket) compose(tenant string, key BucketKey) TenantKey {
+	return TenantKey(tenant + t.bucket.separator + string(key))
+}
@@ -0,0 +1,48 @@
+package lexorank
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTenantBucket(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	tb := NewTenantBucket(NewBucket(WithGenerator(NewGenerator(WithCharacterSet(charSet), WithInitial("5")))))
+
+	key, err := tb.InitialIn("acme", "")
+	noError(t, err)
+	if key != "acme|0|5" {
+		t.Fatalf("unexpected key: %s", key)
+	}
+
+	next, err := tb.Next(key)
+	noError(t, err)
+	if next != "acme|0|6" {
+		t.Fatalf("unexpected key: %s", next)
+	}
+
+	between, err := tb.Between(key, next)
+	noError(t, err)
+	if between != "acme|0|54" {
+		t.Fatalf("unexpected key: %s", between)
+	}
+
+	_, err = tb.Between("acme|0|5", "other|0|6")
+	if !errors.Is(err, ErrTenantMismatch) {
+		t.Fatalf("expected ErrTenantMismatch, got %v", err)
+	}
+
+	_, err = tb.Between("acme|0|5", "acme|1|6")
+	if !errors.Is(err, ErrBucketMismatch) {
+		t.Fatalf("expected ErrBucketMismatch, got %v", err)
+	}
+
+	if _, err := tb.InitialIn("a|b", ""); err == nil {
+		t.Fatal("expected error for tenant containing the separator")
+	}
+	if _, err := tb.Between("", ""); err == nil {
+		t.Fatal("expected error without keys")
+	}
+}
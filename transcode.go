@@ -0,0 +1,74 @@
+package lexorank
+
+import (
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+)
+
+// Transcode converts keys of the character set from into keys of the character set to, preserving their relative order.
+// Each key is interpreted as a fraction in [0, 1) and rewritten with the fewest characters of to that keep it
+// distinct from its neighbors. The result is in the same order as keys, and equal keys are converted to equal keys.
+//
+// It returns an error if two distinct keys represent the same fraction, such as "5" and "50".
+func Transcode(keys []Key, from, to CharacterSet) ([]Key, error) {
+	fromDigits := newKeyDigits(from)
+	toDigits := newKeyDigits(to)
+
+	sorted := slices.Clone(keys)
+	slices.Sort(sorted)
+	sorted = slices.Compact(sorted)
+
+	fractions := make([]fraction, len(sorted))
+	for i, key := range sorted {
+		r, err := fromDigits.toRat(key)
+		if err != nil {
+			return nil, err
+		}
+		fractions[i] = fraction{key, r}
+		if i > 0 && fractions[i-1].rat.Cmp(r) == 0 {
+			return nil, withCode(CodeInvalidArgument, fmt.Errorf("cannot transcode keys representing the same position: %q and %q", sorted[i-1], key))
+		}
+	}
+
+	// lengths[i] is the number of characters needed to distinguish sorted[i] from sorted[i+1].
+	lengths := make([]int, len(sorted))
+	for i := 0; i+1 < len(sorted); i++ {
+		length := 1
+		for toDigits.truncate(fractions[i].rat, length).Cmp(toDigits.truncate(fractions[i+1].rat, length)) == 0 {
+			length++
+		}
+		lengths[i] = length
+	}
+
+	converted := make(map[Key]Key, len(sorted))
+	for i, f := range fractions {
+		length := max(lengths[i], 1)
+		if i > 0 {
+			length = max(length, lengths[i-1])
+		}
+		// Avoid a key of all min characters unless the key is at position 0, since no key can be generated before it.
+		for f.rat.Sign() > 0 && toDigits.truncate(f.rat, length).Sign() == 0 {
+			length++
+		}
+		key := string(toDigits.fromInt(toDigits.truncate(f.rat, length), length))
+		// Trailing min characters do not affect the order against the neighbors.
+		trimmed := strings.TrimRight(key, string(to.Min()))
+		if trimmed == "" {
+			trimmed = key[:len(string(to.Min()))]
+		}
+		converted[f.key] = Key(trimmed)
+	}
+
+	result := make([]Key, len(keys))
+	for i, key := range keys {
+		result[i] = converted[key]
+	}
+	return result, nil
+}
+
+type fraction struct {
+	key Key
+	rat *big.Rat
+}
@@ -0,0 +1,38 @@
+package lexorank
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestTranscode(t *testing.T) {
+	base10, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+	base2, err := NewASCIICharacterSet("01")
+	noError(t, err)
+
+	t.Run("base10 to base62", func(t *testing.T) {
+		keys := []Key{"5", "1", "9", "55", "555", "1", "0001"}
+		got, err := Transcode(keys, base10, DefaultCharacterSet)
+		noError(t, err)
+		want := []Key{"V", "6", "t", "Y6", "YP", "6", "00N"}
+		if !slices.Equal(got, want) {
+			t.Fatalf("expected %v, got %v", want, got)
+		}
+	})
+
+	t.Run("base10 to base2 preserves order", func(t *testing.T) {
+		keys := []Key{"0001", "0002", "1", "15", "2", "5", "5001", "99", "999"}
+		got, err := Transcode(keys, base10, base2)
+		noError(t, err)
+		if !slices.IsSorted(got) || len(slices.Compact(slices.Clone(got))) != len(got) {
+			t.Fatalf("expected strictly sorted keys, got %v", got)
+		}
+	})
+
+	t.Run("error on same position", func(t *testing.T) {
+		if _, err := Transcode([]Key{"5", "50"}, base10, DefaultCharacterSet); err == nil {
+			t.Fatal("expected error, but got nil")
+		}
+	})
+}
@@ -0,0 +1,42 @@
+package lexorank
+
+// KeyTransformer transforms keys stored outside of the Generator, such as an order-preserving encoding or an
+// obfuscation per tenant. Encode must preserve the order of keys, and Decode must invert Encode.
+type KeyTransformer interface {
+	Encode(key Key) (Key, error)
+	Decode(key Key) (Key, error)
+}
+
+// WithTransformer returns a GeneratorOption that sets the KeyTransformer of the Generator.
+// Keys given to the Generator are decoded before generation, and generated keys are encoded.
+func WithTransformer(t KeyTransformer) GeneratorOption {
+	return func(g *Generator) {
+		g.transformer = t
+	}
+}
+
+// transform decodes the keys, calls f with the Generator without the transformer and encodes the result.
+func (g *Generator) transform(prevKey, nextKey Key, f func(g *Generator, prevKey, nextKey Key) (Key, error)) (Key, error) {
+	var err error
+	if prevKey != "" {
+		if prevKey, err = g.transformer.Decode(prevKey); err != nil {
+			return "", err
+		}
+	}
+	if nextKey != "" {
+		if nextKey, err = g.transformer.Decode(nextKey); err != nil {
+			return "", err
+		}
+	}
+	raw := *g
+	raw.transformer = nil
+	key, err := f(&raw, prevKey, nextKey)
+	if key == "" {
+		return "", err
+	}
+	encoded, encodeErr := g.transformer.Encode(key)
+	if encodeErr != nil {
+		return "", encodeErr
+	}
+	return encoded, err
+}
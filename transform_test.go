@@ -0,0 +1,45 @@
+package lexorank
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// prefixTransformer prepends a fixed prefix, which preserves the order of keys.
+type prefixTransformer string
+
+func (p prefixTransformer) Encode(key Key) (Key, error) {
+	return Key(p) + key, nil
+}
+
+func (p prefixTransformer) Decode(key Key) (Key, error) {
+	k, ok := strings.CutPrefix(string(key), string(p))
+	if !ok {
+		return "", fmt.Errorf("%q has no prefix %q", key, p)
+	}
+	return Key(k), nil
+}
+
+func TestWithTransformer(t *testing.T) {
+	charSet, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	g := NewGenerator(WithCharacterSet(charSet), WithInitial("5"), WithTransformer(prefixTransformer("t-")))
+
+	key, err := g.Initial()
+	noError(t, err)
+	equalKey(t, key, "t-5")
+
+	key, err = g.Next(key)
+	noError(t, err)
+	equalKey(t, key, "t-6")
+
+	key, err = g.Between("t-5", "t-6")
+	noError(t, err)
+	equalKey(t, key, "t-54")
+
+	if _, err := g.Next("5"); err == nil {
+		t.Fatal("expected error for a key that cannot be decoded")
+	}
+}
@@ -0,0 +1,90 @@
+package lexorank
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// TypedKey is a BucketKey belonging to the TypedBucket of T.
+// Keys of different types cannot be mixed without an explicit conversion.
+type TypedKey[T any] BucketKey
+
+// BucketKey returns the key as a BucketKey.
+func (k TypedKey[T]) BucketKey() BucketKey {
+	return BucketKey(k)
+}
+
+// String returns a string representation of the TypedKey.
+func (k TypedKey[T]) String() string {
+	return string(k)
+}
+
+// TypedBucket is a Bucket whose bucket label is bound to the type T.
+type TypedBucket[T any] struct {
+	bucket *Bucket
+	label  string
+}
+
+// NewTypedBucket creates a new TypedBucket labeled with the name of the type T, e.g. "main.Task".
+// If b is nil, NewBucket() is used.
+func NewTypedBucket[T any](b *Bucket) *TypedBucket[T] {
+	return NewTypedBucketWithNamespace[T](b, func() string {
+		return reflect.TypeFor[T]().String()
+	})
+}
+
+// NewTypedBucketWithNamespace creates a new TypedBucket labeled with the value returned by namespace.
+// If b is nil, NewBucket() is used.
+func NewTypedBucketWithNamespace[T any](b *Bucket, namespace func() string) *TypedBucket[T] {
+	if b == nil {
+		b = NewBucket()
+	}
+	return &TypedBucket[T]{b, namespace()}
+}
+
+// Label returns the bucket label of the keys.
+func (b *TypedBucket[T]) Label() string {
+	return b.label
+}
+
+// Between generates a key that comes between the prev and next keys.
+func (b *TypedBucket[T]) Between(prev, next TypedKey[T]) (TypedKey[T], error) {
+	if prev == "" && next == "" {
+		return b.Initial()
+	}
+	if err := b.check(prev); err != nil {
+		return "", err
+	}
+	if err := b.check(next); err != nil {
+		return "", err
+	}
+	k, err := b.bucket.Between(BucketKey(prev), BucketKey(next))
+	return TypedKey[T](k), err
+}
+
+// Next generates a key that comes after the given key.
+func (b *TypedBucket[T]) Next(key TypedKey[T]) (TypedKey[T], error) {
+	return b.Between(key, "")
+}
+
+// Prev generates a key that comes before the given key.
+func (b *TypedBucket[T]) Prev(key TypedKey[T]) (TypedKey[T], error) {
+	return b.Between("", key)
+}
+
+// Initial generates the initial key of the bucket.
+func (b *TypedBucket[T]) Initial() (TypedKey[T], error) {
+	k, err := b.bucket.InitialIn(b.label)
+	return TypedKey[T](k), err
+}
+
+func (b *TypedBucket[T]) check(key TypedKey[T]) error {
+	if key == "" {
+		return nil
+	}
+	label, _ := b.bucket.SplitBucketKey(BucketKey(key))
+	if label != b.label {
+		return fmt.Errorf("%w: %q != %q", ErrBucketMismatch, label, b.label)
+	}
+	return nil
+}
@@ -0,0 +1,37 @@
+package lexorank
+
+import (
+	"errors"
+	"testing"
+)
+
+type typedTestTask struct{}
+
+func TestTypedBucket(t *testing.T) {
+	b := NewTypedBucket[typedTestTask](nil)
+	if b.Label() != "lexorank.typedTestTask" {
+		t.Fatalf("unexpected label: %s", b.Label())
+	}
+
+	key, err := b.Initial()
+	noError(t, err)
+	equalBucketKey(t, key.BucketKey(), "lexorank.typedTestTask|UUUUUU")
+
+	next, err := b.Next(key)
+	noError(t, err)
+	equalBucketKey(t, next.BucketKey(), "lexorank.typedTestTask|UUUUUV")
+
+	between, err := b.Between(key, next)
+	noError(t, err)
+	equalBucketKey(t, between.BucketKey(), "lexorank.typedTestTask|UUUUUUU")
+
+	columns := NewTypedBucketWithNamespace[string](nil, func() string { return "columns" })
+	column, err := columns.Initial()
+	noError(t, err)
+	equalBucketKey(t, column.BucketKey(), "columns|UUUUUU")
+
+	_, err = b.Next(TypedKey[typedTestTask](column))
+	if !errors.Is(err, ErrBucketMismatch) {
+		t.Fatalf("expected ErrBucketMismatch, got %v", err)
+	}
+}
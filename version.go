@@ -0,0 +1,98 @@
+package lexorank
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// VersionSeparator separates the version marker from the rank in keys of a VersionedGenerator, e.g. "1~abc".
+const VersionSeparator = "~"
+
+// ErrVersionMismatch is returned when the keys given to VersionedGenerator.Between have different versions.
+var ErrVersionMismatch = withCode(CodeVersionMismatch, errors.New("version mismatch"))
+
+// VersionedGenerator generates keys marked with the version of the scheme that generated them, so that the
+// character set or the algorithm can be changed later without invalidating stored keys.
+// A key is generated with the Generator of the version of its neighbors, and new keys use the current version.
+type VersionedGenerator struct {
+	current    string
+	generators map[string]*Generator
+}
+
+// NewVersionedGenerator creates a new VersionedGenerator whose new keys use the Generator of the current version.
+// Versions must not be empty and must not contain the VersionSeparator.
+func NewVersionedGenerator(current string, generators map[string]*Generator) (*VersionedGenerator, error) {
+	for version := range generators {
+		if version == "" || strings.Contains(version, VersionSeparator) {
+			return nil, withCode(CodeInvalidArgument, fmt.Errorf("invalid version %q: it must be non-empty and must not contain %q", version, VersionSeparator))
+		}
+	}
+	if _, ok := generators[current]; !ok {
+		return nil, withCode(CodeInvalidArgument, fmt.Errorf("invalid version %q: no generator", current))
+	}
+	return &VersionedGenerator{current, generators}, nil
+}
+
+// Current returns the version of new keys.
+func (v *VersionedGenerator) Current() string {
+	return v.current
+}
+
+// Parse splits the key into the version and the rank, and returns the Generator of the version.
+func (v *VersionedGenerator) Parse(key Key) (string, Key, *Generator, error) {
+	version, rank, ok := strings.Cut(string(key), VersionSeparator)
+	if !ok {
+		return "", "", nil, withCode(CodeInvalidKey, fmt.Errorf("invalid key %q: version not found", key))
+	}
+	g, ok := v.generators[version]
+	if !ok {
+		return "", "", nil, withCode(CodeInvalidKey, fmt.Errorf("invalid key %q: unknown version %q", key, version))
+	}
+	return version, Key(rank), g, nil
+}
+
+// Between generates a key that comes between the prev and next keys of the same version.
+// If both keys are empty, the initial key of the current version is generated.
+func (v *VersionedGenerator) Between(prev, next Key) (Key, error) {
+	version := v.current
+	g := v.generators[version]
+	var prevRank, nextRank Key
+	if prev != "" {
+		ver, rank, gen, err := v.Parse(prev)
+		if err != nil {
+			return "", err
+		}
+		version, prevRank, g = ver, rank, gen
+	}
+	if next != "" {
+		ver, rank, gen, err := v.Parse(next)
+		if err != nil {
+			return "", err
+		}
+		if prev != "" && ver != version {
+			return "", fmt.Errorf("%w: %q != %q", ErrVersionMismatch, version, ver)
+		}
+		version, nextRank, g = ver, rank, gen
+	}
+	k, err := g.Between(prevRank, nextRank)
+	if k == "" {
+		return "", err
+	}
+	return Key(version+VersionSeparator) + k, err
+}
+
+// Next generates a key that comes after the given key.
+func (v *VersionedGenerator) Next(key Key) (Key, error) {
+	return v.Between(key, "")
+}
+
+// Prev generates a key that comes before the given key.
+func (v *VersionedGenerator) Prev(key Key) (Key, error) {
+	return v.Between("", key)
+}
+
+// Initial generates the initial key of the current version.
+func (v *VersionedGenerator) Initial() (Key, error) {
+	return v.Between("", "")
+}
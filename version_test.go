@@ -0,0 +1,51 @@
+package lexorank
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVersionedGenerator(t *testing.T) {
+	digits, err := NewASCIICharacterSet("0123456789")
+	noError(t, err)
+
+	v, err := NewVersionedGenerator("2", map[string]*Generator{
+		"1": NewGenerator(WithCharacterSet(digits), WithInitial("5")),
+		"2": NewGenerator(WithInitial("U")),
+	})
+	noError(t, err)
+
+	key, err := v.Initial()
+	noError(t, err)
+	equalKey(t, key, "2~U")
+
+	key, err = v.Next("1~5")
+	noError(t, err)
+	equalKey(t, key, "1~6")
+
+	key, err = v.Between("1~5", "1~6")
+	noError(t, err)
+	equalKey(t, key, "1~54")
+
+	version, rank, g, err := v.Parse("2~abc")
+	noError(t, err)
+	if version != "2" || rank != "abc" || g.CharacterSet() != DefaultCharacterSet {
+		t.Fatalf("unexpected parse result: %q %q", version, rank)
+	}
+
+	if _, err := v.Between("1~5", "2~U"); !errors.Is(err, ErrVersionMismatch) {
+		t.Fatalf("expected ErrVersionMismatch, got %v", err)
+	}
+	for _, key := range []Key{"5", "3~5"} {
+		if _, err := v.Next(key); ErrorCode(err) != CodeInvalidKey {
+			t.Fatalf("%q: expected invalid key, got %v", key, err)
+		}
+	}
+
+	if _, err := NewVersionedGenerator("3", map[string]*Generator{"1": NewGenerator()}); err == nil {
+		t.Fatal("expected error for a current version without generator")
+	}
+	if _, err := NewVersionedGenerator("a~b", map[string]*Generator{"a~b": NewGenerator()}); err == nil {
+		t.Fatal("expected error for a version containing the separator")
+	}
+}